@@ -3,12 +3,22 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 	"song-library/internal/app"
 	"song-library/internal/config"
 	"song-library/pkg/logger"
 	"go.uber.org/zap"
 )
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Static API key from AUTH_API_KEYS
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT as "Bearer <token>", signed with HS256 or RS256
 func main() {
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -26,6 +36,13 @@ func main() {
 	}
 	log.Info(ctx, "Application successfully created")
 
+	if len(os.Args) > 1 {
+		if err := application.RunCommand(os.Args[1:]); err != nil {
+			log.Fatal("Command execution error", zap.Error(err))
+		}
+		return
+	}
+
 	if err := application.Run(); err != nil {
 		log.Fatal("Application runtime error", zap.Error(err))
 	}
@@ -2,10 +2,14 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,18 +20,26 @@ import (
 
 	_ "song-library/docs"
 	"song-library/internal/application/usecase"
+	"song-library/internal/application/worker"
 	"song-library/internal/config"
+	"song-library/internal/domain/entity"
+	"song-library/internal/infrastructure/auth"
 	"song-library/internal/infrastructure/database"
+	"song-library/internal/infrastructure/musicinfo"
 	"song-library/internal/infrastructure/persistence/postgres"
 	"song-library/internal/interfaces/http/handler"
+	"song-library/internal/interfaces/http/middleware"
 	"song-library/pkg/logger"
 )
 
 type App struct {
-	config *config.Config
-	router *gin.Engine
-	logger *logger.Logger
-	db     *database.Database
+	config           *config.Config
+	router           *gin.Engine
+	logger           *logger.Logger
+	db               *database.Database
+	songUseCase      *usecase.SongUseCase
+	enrichmentWorker *worker.EnrichmentWorker
+	trashPurger      *worker.TrashPurger
 }
 
 func New(cfg *config.Config, logger *logger.Logger) (*App, error) {
@@ -42,6 +54,15 @@ func New(cfg *config.Config, logger *logger.Logger) (*App, error) {
 	}
 	logger.Debug(ctx, "Database successfully initialized")
 
+	authenticator, err := auth.NewAuthenticator(cfg.Auth)
+	if err != nil {
+		logger.Error(ctx, "Authentication setup error", zap.Error(err))
+		return nil, fmt.Errorf("authentication setup error: %w", err)
+	}
+	if !authenticator.Enabled() {
+		logger.Warn(ctx, "No API keys or JWT keys configured, only read requests will be accepted")
+	}
+
 	app := &App{
 		config: cfg,
 		router: gin.Default(),
@@ -49,30 +70,152 @@ func New(cfg *config.Config, logger *logger.Logger) (*App, error) {
 		db:     db,
 	}
 
-	app.setupRoutes(logger)
+	app.setupRoutes(authenticator, logger)
 	logger.Info(ctx, "Routes successfully configured")
 	
 	return app, nil
 }
 
-func (a *App) setupRoutes(logger *logger.Logger) {
+func (a *App) setupRoutes(authenticator *auth.Authenticator, logger *logger.Logger) {
 	songRepo := postgres.NewSongRepository(a.db.GetDB(), logger)
-	songUseCase := usecase.NewSongUseCase(songRepo, a.config)
+	musicInfoClient := musicinfo.NewHTTPClient(a.config.API, logger)
+	a.enrichmentWorker = worker.NewEnrichmentWorker(songRepo, musicInfoClient, a.config.Enrichment, logger)
+	a.trashPurger = worker.NewTrashPurger(songRepo, a.config.Trash, logger)
+	translationRepo := postgres.NewTranslationRepository(a.db.GetDB(), logger)
+	songUseCase := usecase.NewSongUseCase(songRepo, translationRepo, a.enrichmentWorker)
 	songHandler := handler.NewSongHandler(*songUseCase, logger)
+	a.songUseCase = songUseCase
+
+	translationUseCase := usecase.NewTranslationUseCase(translationRepo)
+	translationHandler := handler.NewTranslationHandler(*translationUseCase, logger)
+
+	artistRepo := postgres.NewArtistRepository(a.db.GetDB(), logger)
+	artistUseCase := usecase.NewArtistUseCase(artistRepo, songRepo)
+	artistHandler := handler.NewArtistHandler(*artistUseCase, logger)
+
+	albumRepo := postgres.NewAlbumRepository(a.db.GetDB(), logger)
+	albumUseCase := usecase.NewAlbumUseCase(albumRepo)
+	albumHandler := handler.NewAlbumHandler(*albumUseCase, logger)
+
+	userRepo := postgres.NewUserRepository(a.db.GetDB(), logger)
+	userUseCase := usecase.NewUserUseCase(userRepo, a.config.Auth)
+	userHandler := handler.NewUserHandler(*userUseCase, logger)
+
+	playlistRepo := postgres.NewPlaylistRepository(a.db.GetDB(), logger)
+	playlistUseCase := usecase.NewPlaylistUseCase(playlistRepo, songRepo)
+	playlistHandler := handler.NewPlaylistHandler(*playlistUseCase, logger)
+
+	feedbackRepo := postgres.NewFeedbackRepository(a.db.GetDB(), logger)
+	feedbackUseCase := usecase.NewFeedbackUseCase(feedbackRepo)
+	feedbackHandler := handler.NewFeedbackHandler(*feedbackUseCase, logger)
 
 	a.router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Reads are public; editors create and change the catalogue, admins
+	// also delete, merge, manage the trash and manage users. Any signed-in
+	// caller may keep playlists, favorites and ratings and count plays; who
+	// owns which playlist is checked per playlist.
+	signedIn := middleware.RequireRole(entity.RoleReader)
+	editor := middleware.RequireRole(entity.RoleEditor)
+	admin := middleware.RequireRole(entity.RoleAdmin)
+
+	// The auth endpoints take credentials in the body; an expired access
+	// token sent along must not stop its own refresh.
+	authRoutes := a.router.Group("/api/v1/auth")
+	{
+		authRoutes.POST("/register", userHandler.Register)
+		authRoutes.POST("/login", userHandler.Login)
+		authRoutes.POST("/refresh", userHandler.Refresh)
+		authRoutes.POST("/logout", userHandler.Logout)
+	}
+
 	v1 := a.router.Group("/api/v1")
+	v1.Use(middleware.Authenticate(authenticator, logger))
 	{
 		songs := v1.Group("/songs")
 		{
-			songs.POST("", songHandler.Create)
+			songs.POST("", editor, songHandler.Create)
 			songs.GET("", songHandler.List)
+			songs.GET("/search", songHandler.Search)
+			songs.POST("/import", editor, songHandler.Import)
+			songs.GET("/export", songHandler.Export)
+			songs.GET("/duplicates", editor, songHandler.ListDuplicates)
+			songs.GET("/trash", admin, songHandler.ListTrash)
+			songs.GET("/favorites", signedIn, feedbackHandler.ListFavorites)
 			songs.GET("/:id", songHandler.Get)
-			songs.PUT("/:id", songHandler.Update)
-			songs.DELETE("/:id", songHandler.Delete)
+			songs.PUT("/:id", editor, songHandler.Update)
+			songs.PATCH("/:id", editor, songHandler.Patch)
+			songs.DELETE("/:id", admin, songHandler.Delete)
 			songs.GET("/:id/text", songHandler.GetSongText)
+			songs.GET("/:id/lrc", songHandler.ExportLRC)
+			songs.PUT("/:id/lrc", editor, songHandler.ImportLRC)
+			songs.DELETE("/:id/lrc", editor, songHandler.DeleteLRC)
+			songs.GET("/:id/lrc/active", songHandler.GetActiveLine)
+			songs.POST("/:id/merge", admin, songHandler.Merge)
+			songs.POST("/:id/restore", admin, songHandler.Restore)
+			songs.GET("/:id/revisions", songHandler.ListRevisions)
+			songs.GET("/:id/revisions/diff", songHandler.DiffRevisions)
+			songs.GET("/:id/revisions/:revision", songHandler.GetRevision)
+			songs.POST("/:id/revisions/:revision/restore", editor, songHandler.RestoreRevision)
+			songs.GET("/:id/feedback", signedIn, feedbackHandler.Get)
+			songs.PUT("/:id/favorite", signedIn, feedbackHandler.AddFavorite)
+			songs.DELETE("/:id/favorite", signedIn, feedbackHandler.RemoveFavorite)
+			songs.PUT("/:id/rating", signedIn, feedbackHandler.Rate)
+			songs.DELETE("/:id/rating", signedIn, feedbackHandler.RemoveRating)
+			songs.POST("/:id/plays", signedIn, feedbackHandler.RecordPlay)
+			songs.GET("/:id/translations", translationHandler.List)
+			songs.POST("/:id/translations", editor, translationHandler.Create)
+			songs.GET("/:id/translations/:lang", translationHandler.Get)
+			songs.PUT("/:id/translations/:lang", editor, translationHandler.Update)
+			songs.DELETE("/:id/translations/:lang", editor, translationHandler.Delete)
 		}
+
+		artists := v1.Group("/artists")
+		{
+			artists.POST("", editor, artistHandler.Create)
+			artists.GET("", artistHandler.List)
+			artists.GET("/:id", artistHandler.Get)
+			artists.PUT("/:id", editor, artistHandler.Update)
+			artists.DELETE("/:id", admin, artistHandler.Delete)
+			artists.GET("/:id/songs", artistHandler.ListSongs)
+		}
+
+		albums := v1.Group("/albums")
+		{
+			albums.POST("", editor, albumHandler.Create)
+			albums.GET("", albumHandler.List)
+			albums.GET("/:id", albumHandler.Get)
+			albums.PUT("/:id", editor, albumHandler.Update)
+			albums.DELETE("/:id", admin, albumHandler.Delete)
+			albums.GET("/:id/tracks", albumHandler.ListTracks)
+			albums.PUT("/:id/tracks/:song_id", editor, albumHandler.SetTrack)
+			albums.DELETE("/:id/tracks/:song_id", editor, albumHandler.RemoveTrack)
+		}
+
+		playlists := v1.Group("/playlists")
+		{
+			playlists.POST("", signedIn, playlistHandler.Create)
+			playlists.GET("", playlistHandler.List)
+			playlists.GET("/:id", playlistHandler.Get)
+			playlists.PUT("/:id", signedIn, playlistHandler.Update)
+			playlists.DELETE("/:id", signedIn, playlistHandler.Delete)
+			playlists.GET("/:id/items", playlistHandler.ListItems)
+			playlists.GET("/:id/export", playlistHandler.Export)
+			playlists.POST("/:id/import", signedIn, playlistHandler.Import)
+			playlists.POST("/:id/items", signedIn, playlistHandler.AddItem)
+			playlists.PUT("/:id/items/:item_id/position", signedIn, playlistHandler.MoveItem)
+			playlists.DELETE("/:id/items/:item_id", signedIn, playlistHandler.RemoveItem)
+		}
+
+		users := v1.Group("/users", admin)
+		{
+			users.GET("", userHandler.List)
+			users.PUT("/:id/role", userHandler.UpdateRole)
+			users.POST("/:id/disable", userHandler.Disable)
+			users.POST("/:id/enable", userHandler.Enable)
+		}
+
+		v1.GET("/suggest", songHandler.Suggest)
 	}
 }
 
@@ -84,6 +227,15 @@ func (a *App) Run() error {
 		Handler: a.router,
 	}
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer func() {
+		stopWorkers()
+		a.enrichmentWorker.Wait()
+		a.trashPurger.Wait()
+	}()
+	a.enrichmentWorker.Start(workerCtx)
+	a.trashPurger.Start(workerCtx)
+
 	errChan := make(chan error, 1)
 
 	go func() {
@@ -120,6 +272,96 @@ func (a *App) Run() error {
 	return nil
 }
 
+// RunCommand executes a one-off maintenance command instead of serving HTTP.
+func (a *App) RunCommand(args []string) error {
+	ctx := context.Background()
+
+	switch args[0] {
+	case "backfill-lyrics":
+		processed, err := a.songUseCase.BackfillSections(ctx, 100)
+		if err != nil {
+			return fmt.Errorf("lyrics backfill error: %w", err)
+		}
+		a.logger.Info(ctx, "Lyrics backfill finished", zap.Int("songs", processed))
+		return nil
+	case "import-songs":
+		return a.importSongs(ctx, args[1:])
+	case "purge-trash":
+		return a.purgeTrash(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// importSongs implements "import-songs [-format csv|json|ndjson] [-enrich] FILE"
+// and writes the import report to stdout as JSON.
+func (a *App) importSongs(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("import-songs", flag.ContinueOnError)
+	format := flags.String("format", "", "file format: csv, json or ndjson (default: from the file extension)")
+	enrich := flags.Bool("enrich", false, "queue the imported songs for enrichment")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: import-songs [-format csv|json|ndjson] [-enrich] FILE")
+	}
+	path := flags.Arg(0)
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			*format = "csv"
+		case ".json":
+			*format = "json"
+		case ".ndjson", ".jsonl":
+			*format = "ndjson"
+		default:
+			return fmt.Errorf("cannot tell the format of %q, use -format", path)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening import file: %w", err)
+	}
+	defer file.Close()
+
+	report, err := a.songUseCase.Import(ctx, file, usecase.ImportFormat(*format), *enrich)
+	if err != nil {
+		return fmt.Errorf("song import error: %w", err)
+	}
+
+	a.logger.Info(ctx, "Song import finished",
+		zap.Int("created", report.Created),
+		zap.Int("skipped", report.Skipped),
+		zap.Int("failed", report.Failed))
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// purgeTrash implements "purge-trash [-older-than DURATION]". It defaults to
+// the configured retention; -older-than 0 empties the trash.
+func (a *App) purgeTrash(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+	olderThan := flags.Duration("older-than", a.config.Trash.Retention, "purge songs deleted longer ago than this")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 || *olderThan < 0 {
+		return fmt.Errorf("usage: purge-trash [-older-than DURATION]")
+	}
+
+	purged, err := a.trashPurger.Purge(ctx, *olderThan)
+	if err != nil {
+		return fmt.Errorf("trash purge error: %w", err)
+	}
+
+	a.logger.Info(ctx, "Trash purge finished", zap.Int("songs", purged))
+	return nil
+}
+
 func (a *App) Close() error {
 	return a.db.Close()
 }
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"song-library/internal/domain/entity"
+	"time"
+)
+
+type CreateAlbumRequest struct {
+	ArtistID    int64  `json:"artist_id" binding:"required"`
+	Title       string `json:"title" binding:"required"`
+	Type        string `json:"type" binding:"omitempty,oneof=LP EP single" enums:"LP,EP,single"`
+	ReleaseDate string `json:"release_date"`
+	CoverLink   string `json:"cover_link" binding:"omitempty,url"`
+}
+
+type UpdateAlbumRequest struct {
+	ArtistID    int64  `json:"artist_id" binding:"required"`
+	Title       string `json:"title" binding:"required"`
+	Type        string `json:"type" binding:"required,oneof=LP EP single" enums:"LP,EP,single"`
+	ReleaseDate string `json:"release_date"`
+	CoverLink   string `json:"cover_link" binding:"omitempty,url"`
+}
+
+type AlbumResponse struct {
+	ID          int64     `json:"id"`
+	ArtistID    int64     `json:"artist_id"`
+	ArtistName  string    `json:"artist_name"`
+	Title       string    `json:"title"`
+	Type        string    `json:"type"`
+	ReleaseDate string    `json:"release_date"`
+	CoverLink   string    `json:"cover_link"`
+	TrackCount  int       `json:"track_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type AlbumListRequest struct {
+	ArtistID int64  `form:"artist_id"`
+	Title    string `form:"title"`
+	Type     string `form:"type" binding:"omitempty,oneof=LP EP single"`
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type AlbumListResponse struct {
+	Albums     []AlbumResponse `json:"albums"`
+	Total      int             `json:"total"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"page_size"`
+	TotalPages int             `json:"total_pages"`
+}
+
+type SetAlbumTrackRequest struct {
+	DiscNumber  int `json:"disc_number" binding:"omitempty,min=1"`
+	TrackNumber int `json:"track_number" binding:"required,min=1"`
+}
+
+type AlbumTrackResponse struct {
+	DiscNumber  int          `json:"disc_number"`
+	TrackNumber int          `json:"track_number"`
+	Song        SongResponse `json:"song"`
+}
+
+type AlbumTracksResponse struct {
+	AlbumID int64                `json:"album_id"`
+	Tracks  []AlbumTrackResponse `json:"tracks"`
+}
+
+func ToAlbumResponse(album *entity.Album) AlbumResponse {
+	return AlbumResponse{
+		ID:          album.ID,
+		ArtistID:    album.ArtistID,
+		ArtistName:  album.ArtistName,
+		Title:       album.Title,
+		Type:        string(album.Type),
+		ReleaseDate: FormatReleaseDate(album.ReleaseDate),
+		CoverLink:   album.CoverLink,
+		TrackCount:  album.TrackCount,
+		CreatedAt:   album.CreatedAt,
+		UpdatedAt:   album.UpdatedAt,
+	}
+}
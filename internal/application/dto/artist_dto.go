@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"song-library/internal/domain/entity"
+	"time"
+)
+
+type CreateArtistRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Country     string `json:"country"`
+	FormedYear  int    `json:"formed_year" binding:"omitempty,min=1000,max=9999"`
+	Description string `json:"description"`
+}
+
+type UpdateArtistRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Country     string `json:"country"`
+	FormedYear  int    `json:"formed_year" binding:"omitempty,min=1000,max=9999"`
+	Description string `json:"description"`
+}
+
+type ArtistResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Country     string    `json:"country"`
+	FormedYear  int       `json:"formed_year,omitempty"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ArtistListRequest struct {
+	Name     string `form:"name"`
+	Country  string `form:"country"`
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type ArtistListResponse struct {
+	Artists    []ArtistResponse `json:"artists"`
+	Total      int              `json:"total"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalPages int              `json:"total_pages"`
+}
+
+type ArtistSongsRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+func ToArtistResponse(artist *entity.Artist) ArtistResponse {
+	return ArtistResponse{
+		ID:          artist.ID,
+		Name:        artist.Name,
+		Country:     artist.Country,
+		FormedYear:  artist.FormedYear,
+		Description: artist.Description,
+		CreatedAt:   artist.CreatedAt,
+		UpdatedAt:   artist.UpdatedAt,
+	}
+}
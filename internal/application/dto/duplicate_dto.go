@@ -0,0 +1,27 @@
+package dto
+
+type DuplicateListRequest struct {
+	Mode          string  `form:"mode,default=normalized" binding:"oneof=normalized fuzzy"`
+	MinSimilarity float64 `form:"min_similarity,default=0.6" binding:"gt=0,lte=1"`
+	Page          int     `form:"page,default=1" binding:"min=1"`
+	PageSize      int     `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type DuplicateGroupResponse struct {
+	Similarity float64        `json:"similarity"`
+	Songs      []SongResponse `json:"songs"`
+}
+
+type DuplicateListResponse struct {
+	Groups     []DuplicateGroupResponse `json:"groups"`
+	Total      int                      `json:"total"`
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"page_size"`
+	TotalPages int                      `json:"total_pages"`
+}
+
+// MergeSongsRequest names the songs to fold into the song being kept. Their
+// order decides which duplicate fills an empty field first.
+type MergeSongsRequest struct {
+	DuplicateIDs []int64 `json:"duplicate_ids" binding:"required,min=1,max=100,dive,min=1"`
+}
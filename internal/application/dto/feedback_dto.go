@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"math"
+
+	"song-library/internal/domain/entity"
+)
+
+type RateSongRequest struct {
+	Rating int `json:"rating" binding:"required,min=1,max=5" example:"4"`
+}
+
+type FavoriteListRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type FavoriteListResponse struct {
+	Songs      []SongResponse `json:"songs"`
+	Total      int            `json:"total"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	TotalPages int            `json:"total_pages"`
+}
+
+// SongStatsResponse holds the aggregates of a song; average_rating is 0
+// while the song has no ratings.
+type SongStatsResponse struct {
+	SongID        int64   `json:"song_id"`
+	FavoriteCount int     `json:"favorite_count"`
+	RatingCount   int     `json:"rating_count"`
+	AverageRating float64 `json:"average_rating"`
+	PlayCount     int64   `json:"play_count"`
+}
+
+// SongFeedbackResponse is the caller's own favorite and rating of a song;
+// rating is left out when the caller has not rated it.
+type SongFeedbackResponse struct {
+	SongID   int64             `json:"song_id"`
+	Favorite bool              `json:"favorite"`
+	Rating   int               `json:"rating,omitempty"`
+	Stats    SongStatsResponse `json:"stats"`
+}
+
+func ToSongStatsResponse(stats *entity.SongStats) SongStatsResponse {
+	return SongStatsResponse{
+		SongID:        stats.SongID,
+		FavoriteCount: stats.FavoriteCount,
+		RatingCount:   stats.RatingCount,
+		AverageRating: RoundRating(stats.AverageRating),
+		PlayCount:     stats.PlayCount,
+	}
+}
+
+func ToSongFeedbackResponse(feedback *entity.SongFeedback) SongFeedbackResponse {
+	return SongFeedbackResponse{
+		SongID:   feedback.SongID,
+		Favorite: feedback.Favorite,
+		Rating:   feedback.Rating,
+		Stats:    ToSongStatsResponse(&feedback.Stats),
+	}
+}
+
+// RoundRating rounds an average rating to two decimals for display.
+func RoundRating(rating float64) float64 {
+	return math.Round(rating*100) / 100
+}
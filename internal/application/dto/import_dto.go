@@ -0,0 +1,42 @@
+package dto
+
+const (
+	ImportCreated = "created"
+	ImportSkipped = "skipped"
+	ImportFailed  = "failed"
+)
+
+// ImportSongRow is one song of a bulk import. CSV files name their columns
+// after the JSON keys.
+type ImportSongRow struct {
+	GroupName   string `json:"group"`
+	SongName    string `json:"song"`
+	ReleaseDate string `json:"release_date"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+type ImportSongsRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json ndjson"`
+	Enrich bool   `form:"enrich"`
+}
+
+// ImportRowResult reports what happened to one row; Row is its 1-based
+// position in the file, not counting the CSV header.
+type ImportRowResult struct {
+	Row    int    `json:"row"`
+	Status string `json:"status" enums:"created,skipped,failed"`
+	ID     int64  `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportReport is the outcome of a bulk import. Error is set when the file
+// turned out to be malformed part way through; rows before that point were
+// still imported.
+type ImportReport struct {
+	Created int               `json:"created"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Error   string            `json:"error,omitempty"`
+	Rows    []ImportRowResult `json:"rows"`
+}
@@ -0,0 +1,137 @@
+package dto
+
+import (
+	"song-library/internal/domain/entity"
+	"time"
+)
+
+type CreatePlaylistRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=public private" example:"private"`
+}
+
+type UpdatePlaylistRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility" binding:"required,oneof=public private" example:"public"`
+}
+
+type PlaylistResponse struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"user_id"`
+	OwnerUsername string    `json:"owner_username"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	Visibility    string    `json:"visibility" enums:"public,private"`
+	ItemCount     int       `json:"item_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type PlaylistListRequest struct {
+	Name     string `form:"name"`
+	UserID   int64  `form:"user_id"`
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type PlaylistListResponse struct {
+	Playlists  []PlaylistResponse `json:"playlists"`
+	Total      int                `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// PlaylistItemsRequest leaves song texts out unless include_lyrics is set.
+type PlaylistItemsRequest struct {
+	IncludeLyrics bool `form:"include_lyrics"`
+	Page          int  `form:"page,default=1" binding:"min=1"`
+	PageSize      int  `form:"page_size,default=50" binding:"min=1,max=500"`
+}
+
+// AddPlaylistItemRequest appends the song unless before_item_id or
+// after_item_id names the item it goes next to.
+type AddPlaylistItemRequest struct {
+	SongID       int64 `json:"song_id" binding:"required,min=1"`
+	BeforeItemID int64 `json:"before_item_id" binding:"omitempty,min=1"`
+	AfterItemID  int64 `json:"after_item_id" binding:"omitempty,min=1"`
+}
+
+// MovePlaylistItemRequest names exactly one of before_item_id and
+// after_item_id.
+type MovePlaylistItemRequest struct {
+	BeforeItemID int64 `json:"before_item_id" binding:"omitempty,min=1"`
+	AfterItemID  int64 `json:"after_item_id" binding:"omitempty,min=1"`
+}
+
+type PlaylistItemResponse struct {
+	ID       int64        `json:"id"`
+	Position int          `json:"position"`
+	AddedAt  time.Time    `json:"added_at"`
+	Song     SongResponse `json:"song"`
+}
+
+type PlaylistItemsResponse struct {
+	Playlist   PlaylistResponse       `json:"playlist"`
+	Items      []PlaylistItemResponse `json:"items"`
+	Total      int                    `json:"total"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"page_size"`
+	TotalPages int                    `json:"total_pages"`
+}
+
+type PlaylistExportRequest struct {
+	Format string `form:"format,default=m3u" binding:"oneof=m3u xspf jspf"`
+}
+
+type PlaylistImportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=m3u xspf jspf"`
+}
+
+const (
+	PlaylistEntryAdded     = "added"
+	PlaylistEntryUnmatched = "unmatched"
+)
+
+// PlaylistImportEntry reports one track of an imported playlist file; Entry
+// is its 1-based position in the file.
+type PlaylistImportEntry struct {
+	Entry     int    `json:"entry"`
+	Status    string `json:"status" enums:"added,unmatched"`
+	GroupName string `json:"group,omitempty"`
+	SongName  string `json:"song,omitempty"`
+	Link      string `json:"link,omitempty"`
+	SongID    int64  `json:"song_id,omitempty"`
+	ItemID    int64  `json:"item_id,omitempty"`
+}
+
+type PlaylistImportReport struct {
+	Added     int                   `json:"added"`
+	Unmatched int                   `json:"unmatched"`
+	Entries   []PlaylistImportEntry `json:"entries"`
+}
+
+func ToPlaylistResponse(playlist *entity.Playlist) PlaylistResponse {
+	return PlaylistResponse{
+		ID:            playlist.ID,
+		UserID:        playlist.UserID,
+		OwnerUsername: playlist.OwnerUsername,
+		Name:          playlist.Name,
+		Description:   playlist.Description,
+		Visibility:    string(playlist.Visibility),
+		ItemCount:     playlist.ItemCount,
+		CreatedAt:     playlist.CreatedAt,
+		UpdatedAt:     playlist.UpdatedAt,
+	}
+}
+
+func ToPlaylistItemResponse(item *entity.PlaylistItem) PlaylistItemResponse {
+	return PlaylistItemResponse{
+		ID:       item.ID,
+		Position: item.Position,
+		AddedAt:  item.AddedAt,
+		Song:     ToSongResponse(item.Song),
+	}
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"song-library/internal/domain/entity"
+	"time"
+)
+
+type RevisionListRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type RevisionResponse struct {
+	SongID       int64     `json:"song_id"`
+	Revision     int       `json:"revision"`
+	GroupName    string    `json:"group_name"`
+	SongName     string    `json:"song_name"`
+	ReleaseDate  string    `json:"release_date"`
+	Text         string    `json:"text"`
+	Link         string    `json:"link"`
+	Editor       string    `json:"editor"`
+	RestoredFrom int       `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type RevisionListResponse struct {
+	SongID     int64              `json:"song_id"`
+	Revisions  []RevisionResponse `json:"revisions"`
+	Total      int                `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// RevisionDiffRequest compares revision From with revision To, or with the
+// current state of the song when To is omitted.
+type RevisionDiffRequest struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"omitempty,min=1"`
+}
+
+type FieldChange struct {
+	Field string `json:"field" enums:"group_name,song_name,release_date,link"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type LineChange struct {
+	Op      string `json:"op" enums:"equal,insert,delete"`
+	Text    string `json:"text"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+}
+
+type RevisionDiffResponse struct {
+	SongID      int64         `json:"song_id"`
+	From        int           `json:"from"`
+	To          *int          `json:"to"`
+	Fields      []FieldChange `json:"fields"`
+	TextChanged bool          `json:"text_changed"`
+	Lines       []LineChange  `json:"lines"`
+}
+
+func ToRevisionResponse(revision *entity.SongRevision) RevisionResponse {
+	return RevisionResponse{
+		SongID:       revision.SongID,
+		Revision:     revision.Revision,
+		GroupName:    revision.GroupName,
+		SongName:     revision.SongName,
+		ReleaseDate:  FormatReleaseDate(revision.ReleaseDate),
+		Text:         revision.Text,
+		Link:         revision.Link,
+		Editor:       revision.Editor,
+		RestoredFrom: revision.RestoredFrom,
+		CreatedAt:    revision.CreatedAt,
+	}
+}
@@ -19,59 +19,202 @@ type UpdateSongRequest struct {
 }
 
 type SongResponse struct {
-	ID          int64     `json:"id"`
-	GroupName   string    `json:"group_name"`
-	SongName    string    `json:"song_name"`
-	ReleaseDate string    `json:"release_date"`
-	Text        string    `json:"text"`
-	Link        string    `json:"link"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID               int64      `json:"id"`
+	ArtistID         int64      `json:"artist_id"`
+	GroupName        string     `json:"group_name"`
+	SongName         string     `json:"song_name"`
+	ReleaseDate      string     `json:"release_date"`
+	Text             string     `json:"text"`
+	Link             string     `json:"link"`
+	EnrichmentStatus string     `json:"enrichment_status" enums:"pending,done,failed"`
+	EnrichmentError  string     `json:"enrichment_error,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	Version          int        `json:"version"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
+	FavoriteCount    int        `json:"favorite_count"`
+	RatingCount      int        `json:"rating_count"`
+	AverageRating    float64    `json:"average_rating"`
+	PlayCount        int64      `json:"play_count"`
 }
 
 type SongListRequest struct {
+	ArtistID    int64  `form:"artist_id"`
 	GroupName   string `form:"group_name"`
 	SongName    string `form:"song_name"`
+	NameMatch   string `form:"name_match" binding:"omitempty,oneof=contains prefix exact fuzzy"`
 	ReleaseDate string `form:"release_date"`
 	Text        string `form:"text"`
 	Link        string `form:"link"`
-	Page        int    `form:"page,default=1"`
-	PageSize    int    `form:"page_size,default=10"`
+	// Dates use YYYY-MM-DD like release_date, timestamps RFC 3339.
+	ReleasedFrom string `form:"released_from"`
+	ReleasedTo   string `form:"released_to"`
+	Year         int    `form:"year" binding:"omitempty,min=1"`
+	Decade       int    `form:"decade" binding:"omitempty,min=0"`
+	CreatedFrom  string `form:"created_from"`
+	CreatedTo    string `form:"created_to"`
+	UpdatedFrom  string `form:"updated_from"`
+	UpdatedTo    string `form:"updated_to"`
+	HasLyrics    *bool  `form:"has_lyrics"`
+	HasLink      *bool  `form:"has_link"`
+	// Lower bounds on the aggregates of favorites, ratings and plays.
+	MinAverageRating float64 `form:"min_average_rating" binding:"min=0,max=5"`
+	MinRatingCount   int     `form:"min_rating_count" binding:"min=0"`
+	MinFavoriteCount int     `form:"min_favorite_count" binding:"min=0"`
+	MinPlayCount     int64   `form:"min_play_count" binding:"min=0"`
+	// MinSimilarity only applies to name_match=fuzzy.
+	MinSimilarity float64 `form:"min_similarity,default=0.3" binding:"gt=0,lte=1"`
+	Page        int    `form:"page,default=1" binding:"min=1"`
+	PageSize    int    `form:"page_size,default=10" binding:"min=1,max=100"`
+	Sort        string `form:"sort"`
+	Cursor      string `form:"cursor"`
+	WithTotal   bool   `form:"with_total,default=true"`
 }
 
+// SongListResponse pages either by number or by cursor. Total and TotalPages
+// are left out when the request set with_total=false, Page when it used a
+// cursor. DidYouMean is only set when a name filter found nothing.
 type SongListResponse struct {
-	Songs      []SongResponse `json:"songs"`
-	Total      int           `json:"total"`
-	Page       int           `json:"page"`
-	PageSize   int           `json:"page_size"`
-	TotalPages int           `json:"total_pages"`
+	Songs      []SongResponse  `json:"songs"`
+	Total      *int            `json:"total,omitempty"`
+	Page       int             `json:"page,omitempty"`
+	PageSize   int             `json:"page_size"`
+	TotalPages *int            `json:"total_pages,omitempty"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	PrevCursor string          `json:"prev_cursor,omitempty"`
+	DidYouMean *NameSuggestion `json:"did_you_mean,omitempty"`
+}
+
+// NameSuggestion proposes known group and song names close to the ones that
+// were searched for.
+type NameSuggestion struct {
+	GroupName string `json:"group_name,omitempty"`
+	SongName  string `json:"song_name,omitempty"`
+}
+
+// SongExportRequest takes the list filters; pagination fields are ignored.
+type SongExportRequest struct {
+	SongListRequest
+	Format string `form:"format,default=ndjson" binding:"oneof=ndjson csv json m3u xspf jspf"`
 }
 
 type GetSongTextRequest struct {
-	Page     int `form:"page,default=1"`
-	PageSize int `form:"page_size,default=10"`
+	Type       string `form:"type" binding:"omitempty,oneof=verse chorus bridge intro outro"`
+	Expand     bool   `form:"expand"`
+	Lang       string `form:"lang" binding:"required_if=SideBySide true"`
+	SideBySide bool   `form:"side_by_side"`
+	Page       int    `form:"page,default=1" binding:"min=1"`
+	PageSize   int    `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type SongSectionResponse struct {
+	Type    string   `json:"type" enums:"verse,chorus,bridge,intro,outro"`
+	Ordinal int      `json:"ordinal"`
+	Lines   []string `json:"lines"`
+	Repeat  bool     `json:"repeat"`
+}
+
+// SongTextPair is one row of the side-by-side view; either side is empty when
+// the original and the translation have a different number of sections.
+type SongTextPair struct {
+	Original    string `json:"original"`
+	Translation string `json:"translation"`
 }
 
 type SongTextResponse struct {
-	ID          int64    `json:"id"`
-	GroupName   string   `json:"group_name"`
-	SongName    string   `json:"song_name"`
-	Verses      []string `json:"verses"`
-	TotalVerses int      `json:"total_verses"`
-	Page        int      `json:"page"`
-	PageSize    int      `json:"page_size"`
-	TotalPages  int      `json:"total_pages"`
+	ID          int64                 `json:"id"`
+	GroupName   string                `json:"group_name"`
+	SongName    string                `json:"song_name"`
+	Language    string                `json:"language,omitempty"`
+	Verses      []string              `json:"verses"`
+	Sections    []SongSectionResponse `json:"sections"`
+	Pairs       []SongTextPair        `json:"pairs,omitempty"`
+	TotalVerses int                   `json:"total_verses"`
+	Page        int                   `json:"page"`
+	PageSize    int                   `json:"page_size"`
+	TotalPages  int                   `json:"total_pages"`
+}
+
+type SuggestRequest struct {
+	Query string `form:"q" binding:"required"`
+	Type  string `form:"type,default=all" binding:"oneof=all group song"`
+	Limit int    `form:"limit,default=5" binding:"min=1,max=20"`
+}
+
+// SuggestResponse lists group and song names that complete the query, each
+// in alphabetical order; a list is empty when its type was not asked for.
+type SuggestResponse struct {
+	Query  string   `json:"query"`
+	Groups []string `json:"groups"`
+	Songs  []string `json:"songs"`
+}
+
+type TrashListRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type TrashListResponse struct {
+	Songs      []SongResponse `json:"songs"`
+	Total      int            `json:"total"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	TotalPages int            `json:"total_pages"`
+}
+
+type SongSearchRequest struct {
+	Query    string `form:"q" binding:"required"`
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type SongSearchHit struct {
+	ID          int64   `json:"id"`
+	GroupName   string  `json:"group_name"`
+	SongName    string  `json:"song_name"`
+	ReleaseDate string  `json:"release_date"`
+	Link        string  `json:"link"`
+	Rank        float64 `json:"rank"`
+	// Snippet is HTML-escaped lyrics with the matched words wrapped in <b> tags.
+	Snippet string `json:"snippet"`
+}
+
+type SongSearchResponse struct {
+	Results    []SongSearchHit `json:"results"`
+	Total      int             `json:"total"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"page_size"`
+	TotalPages int             `json:"total_pages"`
 }
 
 func ToSongResponse(song *entity.Song) SongResponse {
-	return SongResponse{
-		ID:          song.ID,
-		GroupName:   song.GroupName,
-		SongName:    song.SongName,
-		ReleaseDate: song.ReleaseDate.Format("02-01-2006"),
-		Text:        song.Text,
-		Link:        song.Link,
-		CreatedAt:   song.CreatedAt,
-		UpdatedAt:   song.UpdatedAt,
+	response := SongResponse{
+		ID:               song.ID,
+		ArtistID:         song.ArtistID,
+		GroupName:        song.GroupName,
+		SongName:         song.SongName,
+		ReleaseDate:      FormatReleaseDate(song.ReleaseDate),
+		Text:             song.Text,
+		Link:             song.Link,
+		EnrichmentStatus: string(song.EnrichmentStatus),
+		EnrichmentError:  song.EnrichmentError,
+		CreatedAt:        song.CreatedAt,
+		UpdatedAt:        song.UpdatedAt,
+		Version:          song.Version,
+		FavoriteCount:    song.FavoriteCount,
+		RatingCount:      song.RatingCount,
+		AverageRating:    RoundRating(song.AverageRating),
+		PlayCount:        song.PlayCount,
+	}
+	if !song.DeletedAt.IsZero() {
+		response.DeletedAt = &song.DeletedAt
+	}
+	return response
+}
+
+func FormatReleaseDate(releaseDate time.Time) string {
+	if releaseDate.IsZero() {
+		return ""
 	}
+	return releaseDate.Format("02-01-2006")
 }
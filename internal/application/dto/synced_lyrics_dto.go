@@ -0,0 +1,58 @@
+package dto
+
+import "song-library/internal/domain/entity"
+
+type SyncedWordResponse struct {
+	StartMs int64  `json:"start_ms"`
+	Text    string `json:"text"`
+}
+
+type SyncedLineResponse struct {
+	Index   int                  `json:"index"`
+	StartMs int64                `json:"start_ms"`
+	EndMs   *int64               `json:"end_ms,omitempty"`
+	Text    string               `json:"text"`
+	Words   []SyncedWordResponse `json:"words,omitempty"`
+}
+
+type SyncedLyricsResponse struct {
+	ID         int64                `json:"id"`
+	Lines      []SyncedLineResponse `json:"lines"`
+	TotalLines int                  `json:"total_lines"`
+}
+
+type ActiveLineRequest struct {
+	At int64 `form:"at" binding:"min=0"`
+}
+
+type ActiveLineResponse struct {
+	ID   int64               `json:"id"`
+	AtMs int64               `json:"at_ms"`
+	Line *SyncedLineResponse `json:"line"`
+	Next *SyncedLineResponse `json:"next"`
+}
+
+// ToSyncedLineResponse converts lines[index]; the line ends where the next
+// one starts, so the last line has no end.
+func ToSyncedLineResponse(lines []entity.SyncedLine, index int) SyncedLineResponse {
+	line := lines[index]
+	response := SyncedLineResponse{
+		Index:   index,
+		StartMs: line.Start.Milliseconds(),
+		Text:    line.Text,
+	}
+
+	if index+1 < len(lines) {
+		end := lines[index+1].Start.Milliseconds()
+		response.EndMs = &end
+	}
+
+	for _, word := range line.Words {
+		response.Words = append(response.Words, SyncedWordResponse{
+			StartMs: word.Start.Milliseconds(),
+			Text:    word.Text,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"song-library/internal/domain/entity"
+	"time"
+)
+
+type CreateTranslationRequest struct {
+	Language string `json:"language" binding:"required" example:"pt-BR"`
+	Text     string `json:"text" binding:"required"`
+}
+
+type UpdateTranslationRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+type TranslationResponse struct {
+	SongID    int64     `json:"song_id"`
+	Language  string    `json:"language"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TranslationListResponse struct {
+	SongID       int64                 `json:"song_id"`
+	Translations []TranslationResponse `json:"translations"`
+}
+
+func ToTranslationResponse(translation *entity.SongTranslation) TranslationResponse {
+	return TranslationResponse{
+		SongID:    translation.SongID,
+		Language:  translation.Language,
+		Text:      translation.Text,
+		CreatedAt: translation.CreatedAt,
+		UpdatedAt: translation.UpdatedAt,
+	}
+}
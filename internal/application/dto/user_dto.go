@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"song-library/internal/domain/entity"
+	"time"
+)
+
+type RegisterRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=64"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse is returned by register, login and refresh. The refresh token
+// can be used once; every refresh returns a new one.
+type TokenResponse struct {
+	AccessToken      string       `json:"access_token"`
+	TokenType        string       `json:"token_type" example:"Bearer"`
+	ExpiresIn        int          `json:"expires_in" example:"900"`
+	RefreshToken     string       `json:"refresh_token"`
+	RefreshExpiresIn int          `json:"refresh_expires_in" example:"2592000"`
+	User             UserResponse `json:"user"`
+}
+
+type UserResponse struct {
+	ID         int64      `json:"id"`
+	Username   string     `json:"username"`
+	Role       string     `json:"role" example:"reader"`
+	Disabled   bool       `json:"disabled"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type UserListRequest struct {
+	Username string `form:"username"`
+	Role     string `form:"role" binding:"omitempty,oneof=reader editor admin"`
+	Disabled *bool  `form:"disabled"`
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type UserListResponse struct {
+	Users      []UserResponse `json:"users"`
+	Total      int            `json:"total"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	TotalPages int            `json:"total_pages"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=reader editor admin" example:"editor"`
+}
+
+func ToUserResponse(user *entity.User) UserResponse {
+	response := UserResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		Role:      string(user.Role),
+		Disabled:  user.Disabled(),
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+	if user.Disabled() {
+		disabledAt := user.DisabledAt
+		response.DisabledAt = &disabledAt
+	}
+	return response
+}
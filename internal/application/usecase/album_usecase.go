@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+)
+
+type AlbumUseCase struct {
+	repo repository.AlbumRepository
+}
+
+func NewAlbumUseCase(repo repository.AlbumRepository) *AlbumUseCase {
+	return &AlbumUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *AlbumUseCase) Create(ctx context.Context, req *dto.CreateAlbumRequest) (*dto.AlbumResponse, error) {
+	album := &entity.Album{
+		ArtistID:  req.ArtistID,
+		Title:     req.Title,
+		Type:      entity.AlbumType(req.Type),
+		CoverLink: req.CoverLink,
+	}
+	if album.Type == "" {
+		album.Type = entity.AlbumTypeLP
+	}
+
+	releaseDate, err := parseOptionalDate(req.ReleaseDate)
+	if err != nil {
+		return nil, err
+	}
+	album.ReleaseDate = releaseDate
+
+	if err := uc.repo.Create(ctx, album); err != nil {
+		return nil, fmt.Errorf("error creating album: %w", err)
+	}
+
+	response := dto.ToAlbumResponse(album)
+	return &response, nil
+}
+
+func (uc *AlbumUseCase) Update(ctx context.Context, id int64, req *dto.UpdateAlbumRequest) (*dto.AlbumResponse, error) {
+	album := &entity.Album{
+		ID:        id,
+		ArtistID:  req.ArtistID,
+		Title:     req.Title,
+		Type:      entity.AlbumType(req.Type),
+		CoverLink: req.CoverLink,
+	}
+
+	releaseDate, err := parseOptionalDate(req.ReleaseDate)
+	if err != nil {
+		return nil, err
+	}
+	album.ReleaseDate = releaseDate
+
+	if err := uc.repo.Update(ctx, album); err != nil {
+		return nil, fmt.Errorf("error updating album: %w", err)
+	}
+
+	response := dto.ToAlbumResponse(album)
+	return &response, nil
+}
+
+func (uc *AlbumUseCase) Delete(ctx context.Context, id int64) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("error deleting album: %w", err)
+	}
+	return nil
+}
+
+func (uc *AlbumUseCase) Get(ctx context.Context, id int64) (*dto.AlbumResponse, error) {
+	album, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting album: %w", err)
+	}
+
+	response := dto.ToAlbumResponse(album)
+	return &response, nil
+}
+
+func (uc *AlbumUseCase) List(ctx context.Context, req *dto.AlbumListRequest) (*dto.AlbumListResponse, error) {
+	filter := &entity.AlbumFilter{
+		ArtistID: req.ArtistID,
+		Title:    req.Title,
+		Type:     entity.AlbumType(req.Type),
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	albums, total, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting album list: %w", err)
+	}
+
+	totalPages := (total + req.PageSize - 1) / req.PageSize
+
+	albumResponses := make([]dto.AlbumResponse, 0, len(albums))
+	for _, album := range albums {
+		albumResponses = append(albumResponses, dto.ToAlbumResponse(album))
+	}
+
+	return &dto.AlbumListResponse{
+		Albums:     albumResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *AlbumUseCase) ListTracks(ctx context.Context, id int64) (*dto.AlbumTracksResponse, error) {
+	if _, err := uc.repo.GetByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("error getting album: %w", err)
+	}
+
+	tracks, err := uc.repo.ListTracks(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting album tracks: %w", err)
+	}
+
+	trackResponses := make([]dto.AlbumTrackResponse, 0, len(tracks))
+	for _, track := range tracks {
+		trackResponses = append(trackResponses, dto.AlbumTrackResponse{
+			DiscNumber:  track.DiscNumber,
+			TrackNumber: track.TrackNumber,
+			Song:        dto.ToSongResponse(track.Song),
+		})
+	}
+
+	return &dto.AlbumTracksResponse{
+		AlbumID: id,
+		Tracks:  trackResponses,
+	}, nil
+}
+
+func (uc *AlbumUseCase) SetTrack(ctx context.Context, albumID, songID int64, req *dto.SetAlbumTrackRequest) error {
+	track := &entity.AlbumTrack{
+		AlbumID:     albumID,
+		SongID:      songID,
+		DiscNumber:  req.DiscNumber,
+		TrackNumber: req.TrackNumber,
+	}
+	if track.DiscNumber == 0 {
+		track.DiscNumber = 1
+	}
+
+	if err := uc.repo.SetTrack(ctx, track); err != nil {
+		return fmt.Errorf("error setting album track: %w", err)
+	}
+	return nil
+}
+
+func (uc *AlbumUseCase) RemoveTrack(ctx context.Context, albumID, songID int64) error {
+	if err := uc.repo.RemoveTrack(ctx, albumID, songID); err != nil {
+		return fmt.Errorf("error removing album track: %w", err)
+	}
+	return nil
+}
+
+func parseOptionalDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse("02-01-2006", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidDate, err)
+	}
+	return date, nil
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+)
+
+type ArtistUseCase struct {
+	repo     repository.ArtistRepository
+	songRepo repository.SongRepository
+}
+
+func NewArtistUseCase(repo repository.ArtistRepository, songRepo repository.SongRepository) *ArtistUseCase {
+	return &ArtistUseCase{
+		repo:     repo,
+		songRepo: songRepo,
+	}
+}
+
+func (uc *ArtistUseCase) Create(ctx context.Context, req *dto.CreateArtistRequest) (*dto.ArtistResponse, error) {
+	artist := &entity.Artist{
+		Name:        req.Name,
+		Country:     req.Country,
+		FormedYear:  req.FormedYear,
+		Description: req.Description,
+	}
+
+	if err := uc.repo.Create(ctx, artist); err != nil {
+		return nil, fmt.Errorf("error creating artist: %w", err)
+	}
+
+	response := dto.ToArtistResponse(artist)
+	return &response, nil
+}
+
+func (uc *ArtistUseCase) Update(ctx context.Context, id int64, req *dto.UpdateArtistRequest) (*dto.ArtistResponse, error) {
+	artist := &entity.Artist{
+		ID:          id,
+		Name:        req.Name,
+		Country:     req.Country,
+		FormedYear:  req.FormedYear,
+		Description: req.Description,
+	}
+
+	if err := uc.repo.Update(ctx, artist); err != nil {
+		return nil, fmt.Errorf("error updating artist: %w", err)
+	}
+
+	response := dto.ToArtistResponse(artist)
+	return &response, nil
+}
+
+func (uc *ArtistUseCase) Delete(ctx context.Context, id int64) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("error deleting artist: %w", err)
+	}
+	return nil
+}
+
+func (uc *ArtistUseCase) Get(ctx context.Context, id int64) (*dto.ArtistResponse, error) {
+	artist, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting artist: %w", err)
+	}
+
+	response := dto.ToArtistResponse(artist)
+	return &response, nil
+}
+
+func (uc *ArtistUseCase) List(ctx context.Context, req *dto.ArtistListRequest) (*dto.ArtistListResponse, error) {
+	filter := &entity.ArtistFilter{
+		Name:     req.Name,
+		Country:  req.Country,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	artists, total, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting artist list: %w", err)
+	}
+
+	totalPages := (total + req.PageSize - 1) / req.PageSize
+
+	artistResponses := make([]dto.ArtistResponse, 0, len(artists))
+	for _, artist := range artists {
+		artistResponses = append(artistResponses, dto.ToArtistResponse(artist))
+	}
+
+	return &dto.ArtistListResponse{
+		Artists:    artistResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *ArtistUseCase) ListSongs(ctx context.Context, id int64, req *dto.ArtistSongsRequest) (*dto.SongListResponse, error) {
+	if _, err := uc.repo.GetByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("error getting artist: %w", err)
+	}
+
+	filter := &entity.SongFilter{
+		ArtistID: id,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	songs, total, err := uc.songRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting artist songs: %w", err)
+	}
+
+	totalPages := (total + req.PageSize - 1) / req.PageSize
+
+	songResponses := make([]dto.SongResponse, 0, len(songs))
+	for _, song := range songs {
+		songResponses = append(songResponses, dto.ToSongResponse(song))
+	}
+
+	return &dto.SongListResponse{
+		Songs:      songResponses,
+		Total:      &total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: &totalPages,
+	}, nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import "errors"
+
+var (
+	ErrInvalidDate      = errors.New("invalid date format, use DD-MM-YYYY")
+	ErrInvalidLanguage  = errors.New("invalid language tag, use BCP-47 such as en or pt-BR")
+	ErrInvalidSongPatch = errors.New("patched song is invalid")
+	ErrInvalidImport    = errors.New("invalid import file")
+	ErrInvalidCursor    = errors.New("invalid cursor")
+	ErrInvalidFilter    = errors.New("invalid filter")
+	ErrSuggestTimeout   = errors.New("suggestions took too long")
+	ErrInvalidMerge     = errors.New("invalid merge")
+
+	ErrInvalidUsername     = errors.New("username may only contain letters, digits, '.', '_' and '-'")
+	ErrPasswordTooLong     = errors.New("password must be at most 72 bytes long")
+	ErrInvalidCredentials  = errors.New("invalid username or password")
+	ErrTokenIssuerDisabled = errors.New("logins need AUTH_JWT_HS256_SECRET to sign access tokens")
+
+	ErrPlaylistNeedsUser     = errors.New("playlists belong to user accounts, log in as a user")
+	ErrPlaylistForbidden     = errors.New("only the owner of a playlist can change it")
+	ErrInvalidPlaylistAnchor = errors.New("invalid playlist position")
+
+	ErrFeedbackNeedsUser = errors.New("favorites and ratings belong to user accounts, log in as a user")
+)
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+)
+
+// FeedbackUseCase lets users favorite and rate songs and lets any signed-in
+// caller count plays. Favorites and ratings are kept per user account, so
+// callers authenticated by an API key or a token without a user cannot give
+// them.
+type FeedbackUseCase struct {
+	repo repository.FeedbackRepository
+}
+
+func NewFeedbackUseCase(repo repository.FeedbackRepository) *FeedbackUseCase {
+	return &FeedbackUseCase{repo: repo}
+}
+
+func (uc *FeedbackUseCase) Get(ctx context.Context, caller *entity.Principal, songID int64) (*dto.SongFeedbackResponse, error) {
+	userID, err := feedbackUser(caller)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback, err := uc.repo.GetFeedback(ctx, userID, songID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting song feedback: %w", err)
+	}
+
+	response := dto.ToSongFeedbackResponse(feedback)
+	return &response, nil
+}
+
+func (uc *FeedbackUseCase) SetFavorite(ctx context.Context, caller *entity.Principal, songID int64, favorite bool) (*dto.SongStatsResponse, error) {
+	userID, err := feedbackUser(caller)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats *entity.SongStats
+	if favorite {
+		stats, err = uc.repo.AddFavorite(ctx, userID, songID)
+	} else {
+		stats, err = uc.repo.RemoveFavorite(ctx, userID, songID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error updating favorite: %w", err)
+	}
+
+	response := dto.ToSongStatsResponse(stats)
+	return &response, nil
+}
+
+func (uc *FeedbackUseCase) ListFavorites(ctx context.Context, caller *entity.Principal, req *dto.FavoriteListRequest) (*dto.FavoriteListResponse, error) {
+	userID, err := feedbackUser(caller)
+	if err != nil {
+		return nil, err
+	}
+
+	songs, total, err := uc.repo.ListFavorites(ctx, userID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error getting favorites: %w", err)
+	}
+
+	songResponses := make([]dto.SongResponse, 0, len(songs))
+	for _, song := range songs {
+		songResponses = append(songResponses, dto.ToSongResponse(song))
+	}
+
+	return &dto.FavoriteListResponse{
+		Songs:      songResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: (total + req.PageSize - 1) / req.PageSize,
+	}, nil
+}
+
+func (uc *FeedbackUseCase) Rate(ctx context.Context, caller *entity.Principal, songID int64, req *dto.RateSongRequest) (*dto.SongStatsResponse, error) {
+	userID, err := feedbackUser(caller)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.repo.Rate(ctx, userID, songID, req.Rating)
+	if err != nil {
+		return nil, fmt.Errorf("error rating song: %w", err)
+	}
+
+	response := dto.ToSongStatsResponse(stats)
+	return &response, nil
+}
+
+func (uc *FeedbackUseCase) RemoveRating(ctx context.Context, caller *entity.Principal, songID int64) (*dto.SongStatsResponse, error) {
+	userID, err := feedbackUser(caller)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.repo.RemoveRating(ctx, userID, songID)
+	if err != nil {
+		return nil, fmt.Errorf("error removing rating: %w", err)
+	}
+
+	response := dto.ToSongStatsResponse(stats)
+	return &response, nil
+}
+
+// RecordPlay counts a play of the song, attributed to the caller's user
+// account when there is one.
+func (uc *FeedbackUseCase) RecordPlay(ctx context.Context, caller *entity.Principal, songID int64) (*dto.SongStatsResponse, error) {
+	var userID int64
+	if caller != nil {
+		userID = caller.UserID
+	}
+
+	stats, err := uc.repo.RecordPlay(ctx, songID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error recording play: %w", err)
+	}
+
+	response := dto.ToSongStatsResponse(stats)
+	return &response, nil
+}
+
+func feedbackUser(caller *entity.Principal) (int64, error) {
+	if caller == nil || caller.UserID == 0 {
+		return 0, ErrFeedbackNeedsUser
+	}
+	return caller.UserID, nil
+}
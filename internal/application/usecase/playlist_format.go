@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+)
+
+// maxPlaylistFileEntries bounds the tracks read from one playlist file, all
+// of which are matched in a single query.
+const maxPlaylistFileEntries = 10000
+
+// m3uExportWriter writes extended M3U. Every entry needs a location, so songs
+// without a link are left out.
+type m3uExportWriter struct {
+	w     io.Writer
+	title string
+}
+
+func (e *m3uExportWriter) begin(w io.Writer) error {
+	e.w = w
+	_, err := fmt.Fprintf(w, "#EXTM3U\n#PLAYLIST:%s\n", oneLine(e.title))
+	return err
+}
+
+func (e *m3uExportWriter) write(song dto.SongResponse) error {
+	if song.Link == "" {
+		return nil
+	}
+	_, err := fmt.Fprintf(e.w, "#EXTINF:-1,%s - %s\n%s\n", oneLine(song.GroupName), oneLine(song.SongName), oneLine(song.Link))
+	return err
+}
+
+func (e *m3uExportWriter) end() error {
+	return nil
+}
+
+type xspfExportWriter struct {
+	w     io.Writer
+	title string
+}
+
+func (e *xspfExportWriter) begin(w io.Writer) error {
+	e.w = w
+	_, err := fmt.Fprintf(w, "%s<playlist version=\"1\" xmlns=\"http://xspf.org/ns/0/\">\n  <title>%s</title>\n  <trackList>\n",
+		xml.Header, xmlText(e.title))
+	return err
+}
+
+func (e *xspfExportWriter) write(song dto.SongResponse) error {
+	var track strings.Builder
+	track.WriteString("    <track>\n")
+	if song.Link != "" {
+		fmt.Fprintf(&track, "      <location>%s</location>\n", xmlText(song.Link))
+	}
+	fmt.Fprintf(&track, "      <title>%s</title>\n      <creator>%s</creator>\n    </track>\n",
+		xmlText(song.SongName), xmlText(song.GroupName))
+	_, err := io.WriteString(e.w, track.String())
+	return err
+}
+
+func (e *xspfExportWriter) end() error {
+	_, err := io.WriteString(e.w, "  </trackList>\n</playlist>\n")
+	return err
+}
+
+type jspfTrack struct {
+	Location []string `json:"location,omitempty"`
+	Title    string   `json:"title"`
+	Creator  string   `json:"creator"`
+}
+
+type jspfExportWriter struct {
+	w     io.Writer
+	title string
+	count int
+}
+
+func (e *jspfExportWriter) begin(w io.Writer) error {
+	e.w = w
+	title, err := json.Marshal(e.title)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "{\"playlist\":{\"title\":%s,\"track\":[", title)
+	return err
+}
+
+func (e *jspfExportWriter) write(song dto.SongResponse) error {
+	track := jspfTrack{Title: song.SongName, Creator: song.GroupName}
+	if song.Link != "" {
+		track.Location = []string{song.Link}
+	}
+	data, err := json.Marshal(track)
+	if err != nil {
+		return err
+	}
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ",\n"); err != nil {
+			return err
+		}
+	}
+	e.count++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jspfExportWriter) end() error {
+	_, err := io.WriteString(e.w, "]}}\n")
+	return err
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func xmlText(s string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(s))
+	return escaped.String()
+}
+
+// readPlaylistFile reads the tracks of an extended M3U, XSPF or JSPF file.
+// Group and title come from the creator and title of a track, or from the
+// "Group - Title" of an #EXTINF line, and the link from its first location.
+func readPlaylistFile(r io.Reader, format ImportFormat) ([]entity.PlaylistEntry, error) {
+	var entries []entity.PlaylistEntry
+	var err error
+	switch format {
+	case ImportM3U:
+		entries, err = readM3U(r)
+	case ImportXSPF:
+		entries, err = readXSPF(r)
+	case ImportJSPF:
+		entries, err = readJSPF(r)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidImport, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	if len(entries) > maxPlaylistFileEntries {
+		return nil, fmt.Errorf("%w: playlist file must not have more than %d tracks", ErrInvalidImport, maxPlaylistFileEntries)
+	}
+	return entries, nil
+}
+
+func readM3U(r io.Reader) ([]entity.PlaylistEntry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+
+	var entries []entity.PlaylistEntry
+	var info string
+	for first := true; scanner.Scan(); first = false {
+		line := scanner.Text()
+		if first {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXTINF:"):
+			if _, title, ok := strings.Cut(line, ","); ok {
+				info = title
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			entry := entity.PlaylistEntry{Link: line}
+			if group, song, ok := strings.Cut(info, " - "); ok {
+				entry.GroupName, entry.SongName = strings.TrimSpace(group), strings.TrimSpace(song)
+			} else {
+				entry.SongName = strings.TrimSpace(info)
+			}
+			entries = append(entries, entry)
+			info = ""
+		}
+	}
+	return entries, scanner.Err()
+}
+
+func readXSPF(r io.Reader) ([]entity.PlaylistEntry, error) {
+	var playlist struct {
+		XMLName xml.Name `xml:"playlist"`
+		Tracks  []struct {
+			Location []string `xml:"location"`
+			Title    string   `xml:"title"`
+			Creator  string   `xml:"creator"`
+		} `xml:"trackList>track"`
+	}
+	if err := xml.NewDecoder(r).Decode(&playlist); err != nil {
+		return nil, err
+	}
+
+	entries := make([]entity.PlaylistEntry, 0, len(playlist.Tracks))
+	for _, track := range playlist.Tracks {
+		entries = append(entries, playlistEntry(track.Creator, track.Title, track.Location))
+	}
+	return entries, nil
+}
+
+func readJSPF(r io.Reader) ([]entity.PlaylistEntry, error) {
+	var file struct {
+		Playlist *struct {
+			Tracks []jspfTrack `json:"track"`
+		} `json:"playlist"`
+	}
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+	if file.Playlist == nil {
+		return nil, fmt.Errorf("JSPF file has no playlist object")
+	}
+
+	entries := make([]entity.PlaylistEntry, 0, len(file.Playlist.Tracks))
+	for _, track := range file.Playlist.Tracks {
+		entries = append(entries, playlistEntry(track.Creator, track.Title, track.Location))
+	}
+	return entries, nil
+}
+
+func playlistEntry(creator, title string, locations []string) entity.PlaylistEntry {
+	entry := entity.PlaylistEntry{
+		GroupName: strings.TrimSpace(creator),
+		SongName:  strings.TrimSpace(title),
+	}
+	if len(locations) > 0 {
+		entry.Link = strings.TrimSpace(locations[0])
+	}
+	return entry
+}
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+)
+
+// PlaylistUseCase checks who may see and change playlists: anyone may read
+// public playlists, private ones are hidden from everyone but their owner
+// and admins, and only the owner or an admin may change a playlist. The
+// caller is nil for anonymous requests.
+type PlaylistUseCase struct {
+	repo  repository.PlaylistRepository
+	songs repository.SongRepository
+}
+
+func NewPlaylistUseCase(repo repository.PlaylistRepository, songs repository.SongRepository) *PlaylistUseCase {
+	return &PlaylistUseCase{repo: repo, songs: songs}
+}
+
+func (uc *PlaylistUseCase) Create(ctx context.Context, caller *entity.Principal, req *dto.CreatePlaylistRequest) (*dto.PlaylistResponse, error) {
+	if caller == nil || caller.UserID == 0 {
+		return nil, ErrPlaylistNeedsUser
+	}
+
+	playlist := &entity.Playlist{
+		UserID:      caller.UserID,
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  entity.PlaylistPrivate,
+	}
+	if req.Visibility != "" {
+		playlist.Visibility = entity.PlaylistVisibility(req.Visibility)
+	}
+
+	if err := uc.repo.Create(ctx, playlist); err != nil {
+		return nil, fmt.Errorf("error creating playlist: %w", err)
+	}
+
+	response := dto.ToPlaylistResponse(playlist)
+	return &response, nil
+}
+
+func (uc *PlaylistUseCase) Update(ctx context.Context, caller *entity.Principal, id int64, req *dto.UpdatePlaylistRequest) (*dto.PlaylistResponse, error) {
+	if _, err := uc.editable(ctx, caller, id); err != nil {
+		return nil, err
+	}
+
+	playlist := &entity.Playlist{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  entity.PlaylistVisibility(req.Visibility),
+	}
+	if err := uc.repo.Update(ctx, playlist); err != nil {
+		return nil, fmt.Errorf("error updating playlist: %w", err)
+	}
+
+	response := dto.ToPlaylistResponse(playlist)
+	return &response, nil
+}
+
+func (uc *PlaylistUseCase) Delete(ctx context.Context, caller *entity.Principal, id int64) error {
+	if _, err := uc.editable(ctx, caller, id); err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("error deleting playlist: %w", err)
+	}
+	return nil
+}
+
+func (uc *PlaylistUseCase) Get(ctx context.Context, caller *entity.Principal, id int64) (*dto.PlaylistResponse, error) {
+	playlist, err := uc.visible(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.ToPlaylistResponse(playlist)
+	return &response, nil
+}
+
+func (uc *PlaylistUseCase) List(ctx context.Context, caller *entity.Principal, req *dto.PlaylistListRequest) (*dto.PlaylistListResponse, error) {
+	filter := &entity.PlaylistFilter{
+		Name:     req.Name,
+		UserID:   req.UserID,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	if caller != nil {
+		filter.ViewerID = caller.UserID
+		filter.AllVisible = caller.Role.Allows(entity.RoleAdmin)
+	}
+
+	playlists, total, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting playlist list: %w", err)
+	}
+
+	playlistResponses := make([]dto.PlaylistResponse, 0, len(playlists))
+	for _, playlist := range playlists {
+		playlistResponses = append(playlistResponses, dto.ToPlaylistResponse(playlist))
+	}
+
+	return &dto.PlaylistListResponse{
+		Playlists:  playlistResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: (total + req.PageSize - 1) / req.PageSize,
+	}, nil
+}
+
+func (uc *PlaylistUseCase) ListItems(ctx context.Context, caller *entity.Principal, id int64, req *dto.PlaylistItemsRequest) (*dto.PlaylistItemsResponse, error) {
+	playlist, err := uc.visible(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := uc.repo.ListItems(ctx, id, req.IncludeLyrics, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error getting playlist items: %w", err)
+	}
+
+	itemResponses := make([]dto.PlaylistItemResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, dto.ToPlaylistItemResponse(item))
+	}
+
+	return &dto.PlaylistItemsResponse{
+		Playlist:   dto.ToPlaylistResponse(playlist),
+		Items:      itemResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: (total + req.PageSize - 1) / req.PageSize,
+	}, nil
+}
+
+func (uc *PlaylistUseCase) AddItem(ctx context.Context, caller *entity.Principal, id int64, req *dto.AddPlaylistItemRequest) (*dto.PlaylistItemResponse, error) {
+	if req.BeforeItemID != 0 && req.AfterItemID != 0 {
+		return nil, fmt.Errorf("%w: give before_item_id or after_item_id, not both", ErrInvalidPlaylistAnchor)
+	}
+	if _, err := uc.editable(ctx, caller, id); err != nil {
+		return nil, err
+	}
+
+	item := &entity.PlaylistItem{PlaylistID: id, SongID: req.SongID}
+	anchor := entity.PlaylistAnchor{BeforeItemID: req.BeforeItemID, AfterItemID: req.AfterItemID}
+	if err := uc.repo.AddItem(ctx, item, anchor); err != nil {
+		return nil, fmt.Errorf("error adding playlist item: %w", err)
+	}
+
+	response := dto.ToPlaylistItemResponse(item)
+	return &response, nil
+}
+
+func (uc *PlaylistUseCase) MoveItem(ctx context.Context, caller *entity.Principal, id, itemID int64, req *dto.MovePlaylistItemRequest) (*dto.PlaylistItemResponse, error) {
+	if (req.BeforeItemID == 0) == (req.AfterItemID == 0) {
+		return nil, fmt.Errorf("%w: give exactly one of before_item_id and after_item_id", ErrInvalidPlaylistAnchor)
+	}
+	if req.BeforeItemID == itemID || req.AfterItemID == itemID {
+		return nil, fmt.Errorf("%w: an item cannot be placed next to itself", ErrInvalidPlaylistAnchor)
+	}
+	if _, err := uc.editable(ctx, caller, id); err != nil {
+		return nil, err
+	}
+
+	anchor := entity.PlaylistAnchor{BeforeItemID: req.BeforeItemID, AfterItemID: req.AfterItemID}
+	item, err := uc.repo.MoveItem(ctx, id, itemID, anchor)
+	if err != nil {
+		return nil, fmt.Errorf("error moving playlist item: %w", err)
+	}
+
+	response := dto.ToPlaylistItemResponse(item)
+	return &response, nil
+}
+
+func (uc *PlaylistUseCase) RemoveItem(ctx context.Context, caller *entity.Principal, id, itemID int64) error {
+	if _, err := uc.editable(ctx, caller, id); err != nil {
+		return err
+	}
+
+	if err := uc.repo.RemoveItem(ctx, id, itemID); err != nil {
+		return fmt.Errorf("error removing playlist item: %w", err)
+	}
+	return nil
+}
+
+// Export writes the songs of the playlist to w in playlist order, titled with
+// the playlist name. Items are read a page at a time, so an export running
+// while the playlist is edited may miss or repeat an item.
+func (uc *PlaylistUseCase) Export(ctx context.Context, caller *entity.Principal, id int64, format ExportFormat, w io.Writer) (int, error) {
+	playlist, err := uc.visible(ctx, caller, id)
+	if err != nil {
+		return 0, err
+	}
+
+	writer, err := newExportWriter(format, playlist.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	items, total, err := uc.repo.ListItems(ctx, id, false, 1, exportBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error exporting playlist: %w", err)
+	}
+
+	buffered := bufio.NewWriter(w)
+	if err := writer.begin(buffered); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for page := 1; len(items) > 0; page++ {
+		for _, item := range items {
+			if err := writer.write(dto.ToSongResponse(item.Song)); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if page*exportBatchSize >= total {
+			break
+		}
+		if items, _, err = uc.repo.ListItems(ctx, id, false, page+1, exportBatchSize); err != nil {
+			return count, fmt.Errorf("error exporting playlist: %w", err)
+		}
+	}
+
+	if err := writer.end(); err != nil {
+		return count, err
+	}
+	return count, buffered.Flush()
+}
+
+// Import appends the songs of an M3U, XSPF or JSPF file to the playlist in
+// file order. Tracks that match no song in the library are reported and left
+// out; nothing is created in the library.
+func (uc *PlaylistUseCase) Import(ctx context.Context, caller *entity.Principal, id int64, r io.Reader, format ImportFormat) (*dto.PlaylistImportReport, error) {
+	if _, err := uc.editable(ctx, caller, id); err != nil {
+		return nil, err
+	}
+
+	entries, err := readPlaylistFile(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.PlaylistImportReport{Entries: make([]dto.PlaylistImportEntry, 0, len(entries))}
+	if len(entries) == 0 {
+		return report, nil
+	}
+
+	matches, err := uc.songs.MatchEntries(ctx, entries)
+	if err != nil {
+		return nil, fmt.Errorf("error matching playlist entries: %w", err)
+	}
+
+	var songIDs []int64
+	for i, entry := range entries {
+		result := dto.PlaylistImportEntry{
+			Entry:     i + 1,
+			Status:    dto.PlaylistEntryUnmatched,
+			GroupName: entry.GroupName,
+			SongName:  entry.SongName,
+			Link:      entry.Link,
+		}
+		if song := matches[i]; song != nil {
+			result.Status = dto.PlaylistEntryAdded
+			result.SongID = song.ID
+			songIDs = append(songIDs, song.ID)
+			report.Added++
+		} else {
+			report.Unmatched++
+		}
+		report.Entries = append(report.Entries, result)
+	}
+
+	if len(songIDs) > 0 {
+		itemIDs, err := uc.repo.AppendItems(ctx, id, songIDs)
+		if err != nil {
+			return nil, fmt.Errorf("error adding playlist items: %w", err)
+		}
+		next := 0
+		for i := range report.Entries {
+			if report.Entries[i].Status == dto.PlaylistEntryAdded {
+				report.Entries[i].ItemID = itemIDs[next]
+				next++
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// visible returns the playlist if the caller may read it. Private playlists
+// of others are reported as not found rather than forbidden.
+func (uc *PlaylistUseCase) visible(ctx context.Context, caller *entity.Principal, id int64) (*entity.Playlist, error) {
+	playlist, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting playlist: %w", err)
+	}
+
+	if playlist.Visibility != entity.PlaylistPublic && !ownsPlaylist(caller, playlist) {
+		return nil, repository.ErrPlaylistNotFound
+	}
+	return playlist, nil
+}
+
+func (uc *PlaylistUseCase) editable(ctx context.Context, caller *entity.Principal, id int64) (*entity.Playlist, error) {
+	playlist, err := uc.visible(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ownsPlaylist(caller, playlist) {
+		return nil, ErrPlaylistForbidden
+	}
+	return playlist, nil
+}
+
+func ownsPlaylist(caller *entity.Principal, playlist *entity.Playlist) bool {
+	if caller == nil {
+		return false
+	}
+	return (caller.UserID != 0 && caller.UserID == playlist.UserID) || caller.Role.Allows(entity.RoleAdmin)
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"song-library/internal/domain/entity"
+)
+
+// Cursors are opaque to clients: base64url-encoded JSON of the position.
+
+func encodeSongCursor(cursor entity.SongCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeSongCursor also rejects cursors that were issued for another sort
+// order, since their key values would not line up.
+func decodeSongCursor(value, sortOrder string) (*entity.SongCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor entity.SongCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Sort != sortOrder {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// parseSongSort reads a comma-separated list of fields, each optionally
+// prefixed with - for descending order. Field names are checked by the
+// repository.
+func parseSongSort(value string) []entity.SongSort {
+	var sort []entity.SongSort
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		desc := strings.HasPrefix(field, "-")
+		sort = append(sort, entity.SongSort{
+			Field: strings.TrimPrefix(strings.TrimPrefix(field, "-"), "+"),
+			Desc:  desc,
+		})
+	}
+	return sort
+}
+
+func formatSongSort(sort []entity.SongSort) string {
+	fields := make([]string, len(sort))
+	for i, key := range sort {
+		fields[i] = key.Field
+		if key.Desc {
+			fields[i] = "-" + key.Field
+		}
+	}
+	return strings.Join(fields, ",")
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"song-library/internal/domain/entity"
+)
+
+func TestSongCursorRoundTrip(t *testing.T) {
+	cursors := []entity.SongCursor{
+		{ID: 1},
+		{ID: 42, Sort: "-release_date", Keys: []string{"2020-01-02"}},
+		{ID: 7, Sort: "group_name,-song_name", Keys: []string{"Muse", "Uprising"}, Backward: true},
+	}
+
+	for _, cursor := range cursors {
+		got, err := decodeSongCursor(encodeSongCursor(cursor), cursor.Sort)
+		if err != nil {
+			t.Fatalf("decodeSongCursor(%+v) error = %v", cursor, err)
+		}
+		if !reflect.DeepEqual(*got, cursor) {
+			t.Errorf("decodeSongCursor() = %+v, want %+v", *got, cursor)
+		}
+	}
+}
+
+func TestDecodeSongCursorRejectsInvalidCursors(t *testing.T) {
+	encode := func(data string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(data))
+	}
+	valid := encodeSongCursor(entity.SongCursor{ID: 42, Sort: "song_name", Keys: []string{"Uprising"}})
+
+	tests := []struct {
+		name      string
+		value     string
+		sortOrder string
+	}{
+		{name: "garbage", value: "not a cursor!", sortOrder: ""},
+		{name: "padded base64", value: base64.URLEncoding.EncodeToString([]byte(`{"id":1}`)), sortOrder: ""},
+		{name: "not JSON", value: encode("id=1"), sortOrder: ""},
+		{name: "JSON of the wrong shape", value: encode(`{"id":"one"}`), sortOrder: ""},
+		{name: "missing id", value: encode(`{"sort":"song_name","keys":["a"]}`), sortOrder: "song_name"},
+		{name: "negative id", value: encode(`{"id":-5}`), sortOrder: ""},
+		{name: "other sort order", value: valid, sortOrder: "-song_name"},
+		{name: "edited sort order", value: encode(`{"id":42,"sort":"group_name","keys":["Uprising"]}`), sortOrder: "song_name"},
+		{name: "truncated", value: valid[:len(valid)-3], sortOrder: "song_name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeSongCursor(tt.value, tt.sortOrder); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeSongCursor(%q) error = %v, want ErrInvalidCursor", tt.value, err)
+			}
+		})
+	}
+}
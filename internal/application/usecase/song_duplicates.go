@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+	"song-library/pkg/logger"
+)
+
+// Duplicates reports groups of songs that are probably the same song so that
+// they can be reviewed and merged.
+func (uc *SongUseCase) Duplicates(ctx context.Context, req *dto.DuplicateListRequest) (*dto.DuplicateListResponse, error) {
+	groups, total, err := uc.repo.FindDuplicates(ctx, &entity.DuplicateQuery{
+		Mode:          entity.DuplicateMode(req.Mode),
+		MinSimilarity: req.MinSimilarity,
+		Page:          req.Page,
+		PageSize:      req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error finding duplicate songs: %w", err)
+	}
+
+	response := &dto.DuplicateListResponse{
+		Groups:     make([]dto.DuplicateGroupResponse, 0, len(groups)),
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: (total + req.PageSize - 1) / req.PageSize,
+	}
+	for _, group := range groups {
+		songs := make([]dto.SongResponse, 0, len(group.Songs))
+		for _, song := range group.Songs {
+			songs = append(songs, dto.ToSongResponse(song))
+		}
+		response.Groups = append(response.Groups, dto.DuplicateGroupResponse{
+			Similarity: group.Similarity,
+			Songs:      songs,
+		})
+	}
+	return response, nil
+}
+
+// Merge keeps the song with the given id and folds the duplicates into it:
+// fields the song lacks are filled from them, their translations, synced
+// lyrics and album tracks move over unless the song has its own, and the
+// duplicates are deleted.
+func (uc *SongUseCase) Merge(ctx context.Context, id int64, req *dto.MergeSongsRequest, editor string) (*dto.SongResponse, error) {
+	log := logger.New("debug")
+
+	seen := make(map[int64]bool, len(req.DuplicateIDs))
+	for _, duplicateID := range req.DuplicateIDs {
+		if duplicateID == id {
+			return nil, fmt.Errorf("%w: a song cannot be merged into itself", ErrInvalidMerge)
+		}
+		if seen[duplicateID] {
+			return nil, fmt.Errorf("%w: song %d is listed twice", ErrInvalidMerge, duplicateID)
+		}
+		seen[duplicateID] = true
+	}
+
+	song, err := uc.repo.Merge(ctx, id, req.DuplicateIDs, entity.RevisionInfo{Editor: editor})
+	if err != nil {
+		return nil, fmt.Errorf("error merging songs: %w", err)
+	}
+
+	log.Info(ctx, "Songs merged",
+		zap.Int64("id", id),
+		zap.Int64s("duplicates", req.DuplicateIDs))
+
+	response := dto.ToSongResponse(song)
+	return &response, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+)
+
+// mergeRepo records the merges that reach the repository. Songs it does not
+// know are missing or in the trash. Other methods are not needed and panic
+// through the nil embedded interface.
+type mergeRepo struct {
+	repository.SongRepository
+	live   map[int64]bool
+	merged [][]int64
+}
+
+func (r *mergeRepo) Merge(ctx context.Context, keeperID int64, duplicateIDs []int64, info entity.RevisionInfo) (*entity.Song, error) {
+	for _, id := range append([]int64{keeperID}, duplicateIDs...) {
+		if !r.live[id] {
+			return nil, repository.ErrSongNotFound
+		}
+	}
+	r.merged = append(r.merged, duplicateIDs)
+	return &entity.Song{ID: keeperID}, nil
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         int64
+		duplicates []int64
+		wantErr    error
+	}{
+		{name: "merges the duplicates", id: 1, duplicates: []int64{2, 3}},
+		{name: "into itself", id: 1, duplicates: []int64{2, 1}, wantErr: ErrInvalidMerge},
+		{name: "duplicate listed twice", id: 1, duplicates: []int64{2, 3, 2}, wantErr: ErrInvalidMerge},
+		{name: "missing or trashed duplicate", id: 1, duplicates: []int64{2, 4}, wantErr: repository.ErrSongNotFound},
+		{name: "missing or trashed song", id: 4, duplicates: []int64{2}, wantErr: repository.ErrSongNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mergeRepo{live: map[int64]bool{1: true, 2: true, 3: true}}
+			uc := NewSongUseCase(repo, nil, nil)
+
+			song, err := uc.Merge(context.Background(), tt.id, &dto.MergeSongsRequest{DuplicateIDs: tt.duplicates}, "editor")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Merge() error = %v, want %v", err, tt.wantErr)
+				}
+				if errors.Is(tt.wantErr, ErrInvalidMerge) && len(repo.merged) != 0 {
+					t.Errorf("invalid merge reached the repository: %v", repo.merged)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Merge() error = %v", err)
+			}
+			if song.ID != tt.id || len(repo.merged) != 1 {
+				t.Errorf("Merge() = %+v, merges %v", song, repo.merged)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"song-library/internal/application/dto"
+)
+
+type ExportFormat string
+
+const (
+	ExportNDJSON ExportFormat = "ndjson"
+	ExportCSV    ExportFormat = "csv"
+	ExportJSON   ExportFormat = "json"
+	ExportM3U    ExportFormat = "m3u"
+	ExportXSPF   ExportFormat = "xspf"
+	ExportJSPF   ExportFormat = "jspf"
+)
+
+// exportBatchSize is the number of songs read from the database per query.
+const exportBatchSize = 1000
+
+var exportCSVHeader = []string{
+	"id", "artist_id", "group_name", "song_name", "release_date", "text", "link",
+	"enrichment_status", "created_at", "updated_at", "version",
+}
+
+// Export writes every song matching the list filters to w, ignoring the
+// pagination fields. Songs are read in batches and written as they arrive,
+// so memory use does not grow with the size of the library. Nothing is
+// written to w before the first batch has been read, which lets the caller
+// still report an error that happens up front.
+func (uc *SongUseCase) Export(ctx context.Context, req *dto.SongListRequest, format ExportFormat, w io.Writer) (int, error) {
+	filter, err := songFilter(req)
+	if err != nil {
+		return 0, err
+	}
+
+	writer, err := newExportWriter(format, "Songs")
+	if err != nil {
+		return 0, err
+	}
+
+	it := uc.repo.Iterate(filter, exportBatchSize)
+	more := it.Next(ctx)
+	if err := it.Err(); err != nil {
+		return 0, fmt.Errorf("error exporting songs: %w", err)
+	}
+
+	buffered := bufio.NewWriter(w)
+	if err := writer.begin(buffered); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for ; more; more = it.Next(ctx) {
+		if err := writer.write(dto.ToSongResponse(it.Song())); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		return count, fmt.Errorf("error exporting songs: %w", err)
+	}
+
+	if err := writer.end(); err != nil {
+		return count, err
+	}
+	return count, buffered.Flush()
+}
+
+// newExportWriter returns the writer for format. Playlist formats carry the
+// title; the others have no place for it.
+func newExportWriter(format ExportFormat, title string) (songExportWriter, error) {
+	switch format {
+	case ExportNDJSON:
+		return &ndjsonExportWriter{}, nil
+	case ExportCSV:
+		return &csvExportWriter{}, nil
+	case ExportJSON:
+		return &jsonExportWriter{}, nil
+	case ExportM3U:
+		return &m3uExportWriter{title: title}, nil
+	case ExportXSPF:
+		return &xspfExportWriter{title: title}, nil
+	case ExportJSPF:
+		return &jspfExportWriter{title: title}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+type songExportWriter interface {
+	begin(w io.Writer) error
+	write(song dto.SongResponse) error
+	end() error
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonExportWriter) begin(w io.Writer) error {
+	e.encoder = json.NewEncoder(w)
+	return nil
+}
+
+func (e *ndjsonExportWriter) write(song dto.SongResponse) error {
+	return e.encoder.Encode(song)
+}
+
+func (e *ndjsonExportWriter) end() error {
+	return nil
+}
+
+type jsonExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonExportWriter) begin(w io.Writer) error {
+	e.w = w
+	_, err := io.WriteString(w, "[")
+	return err
+}
+
+func (e *jsonExportWriter) write(song dto.SongResponse) error {
+	data, err := json.Marshal(song)
+	if err != nil {
+		return err
+	}
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ",\n"); err != nil {
+			return err
+		}
+	}
+	e.count++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExportWriter) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func (e *csvExportWriter) begin(w io.Writer) error {
+	e.writer = csv.NewWriter(w)
+	return e.writer.Write(exportCSVHeader)
+}
+
+func (e *csvExportWriter) write(song dto.SongResponse) error {
+	return e.writer.Write([]string{
+		strconv.FormatInt(song.ID, 10),
+		strconv.FormatInt(song.ArtistID, 10),
+		song.GroupName,
+		song.SongName,
+		song.ReleaseDate,
+		song.Text,
+		song.Link,
+		song.EnrichmentStatus,
+		song.CreatedAt.Format(time.RFC3339),
+		song.UpdatedAt.Format(time.RFC3339),
+		strconv.Itoa(song.Version),
+	})
+}
+
+func (e *csvExportWriter) end() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
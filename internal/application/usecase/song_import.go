@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/lyrics"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+type ImportFormat string
+
+const (
+	ImportCSV    ImportFormat = "csv"
+	ImportJSON   ImportFormat = "json"
+	ImportNDJSON ImportFormat = "ndjson"
+	ImportM3U    ImportFormat = "m3u"
+	ImportXSPF   ImportFormat = "xspf"
+	ImportJSPF   ImportFormat = "jspf"
+)
+
+// importBatchSize is the number of songs inserted per transaction.
+const importBatchSize = 500
+
+// maxImportLine bounds a single NDJSON line, which has to hold the lyrics.
+const maxImportLine = 1 << 20
+
+// Import creates songs from a CSV file with a header row, a JSON array or
+// NDJSON. Every row is validated on its own and the report says which rows
+// were created, skipped as duplicates or failed. Duplicates are detected by
+// normalised group and title, both within the file and against the library.
+// With enrich the new songs are queued for the music info API, which only
+// fills the fields a row left empty; otherwise they are stored as they are.
+func (uc *SongUseCase) Import(ctx context.Context, r io.Reader, format ImportFormat, enrich bool) (*dto.ImportReport, error) {
+	log := logger.New("debug")
+
+	decoder, err := newImportDecoder(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.ImportReport{Rows: []dto.ImportRowResult{}}
+	seen := make(map[string]int)
+	batch := make([]*entity.Song, 0, importBatchSize)
+	batchRows := make([]int, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		outcomes, err := uc.repo.CreateBatch(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("error importing songs: %w", err)
+		}
+
+		for i, song := range batch {
+			result := &report.Rows[batchRows[i]]
+			switch {
+			case outcomes[i] == nil:
+				result.Status = dto.ImportCreated
+				result.ID = song.ID
+				report.Created++
+			case errors.Is(outcomes[i], repository.ErrSongAlreadyExists):
+				result.Status = dto.ImportSkipped
+				result.Error = "song already exists"
+				report.Skipped++
+			default:
+				result.Status = dto.ImportFailed
+				result.Error = outcomes[i].Error()
+				report.Failed++
+			}
+		}
+
+		batch = batch[:0]
+		batchRows = batchRows[:0]
+		return nil
+	}
+
+	for row := 1; ; row++ {
+		data, rowErr, err := decoder.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			report.Error = fmt.Sprintf("reading stopped at row %d: %v", row, err)
+			break
+		}
+
+		result := dto.ImportRowResult{Row: row}
+
+		var song *entity.Song
+		if rowErr == nil {
+			song, rowErr = songFromImportRow(data, enrich)
+		}
+		if rowErr != nil {
+			result.Status = dto.ImportFailed
+			result.Error = rowErr.Error()
+			report.Failed++
+			report.Rows = append(report.Rows, result)
+			continue
+		}
+
+		key := importKey(song.GroupName, song.SongName)
+		if first, ok := seen[key]; ok {
+			result.Status = dto.ImportSkipped
+			result.Error = fmt.Sprintf("duplicate of row %d", first)
+			report.Skipped++
+			report.Rows = append(report.Rows, result)
+			continue
+		}
+		seen[key] = row
+
+		report.Rows = append(report.Rows, result)
+		batch = append(batch, song)
+		batchRows = append(batchRows, len(report.Rows)-1)
+
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if enrich && report.Created > 0 && uc.notifier != nil {
+		uc.notifier.Notify()
+	}
+
+	log.Info(ctx, "Songs imported",
+		zap.Int("created", report.Created),
+		zap.Int("skipped", report.Skipped),
+		zap.Int("failed", report.Failed))
+	return report, nil
+}
+
+func songFromImportRow(row dto.ImportSongRow, enrich bool) (*entity.Song, error) {
+	song := &entity.Song{
+		GroupName:        strings.TrimSpace(row.GroupName),
+		SongName:         strings.TrimSpace(row.SongName),
+		Link:             strings.TrimSpace(row.Link),
+		EnrichmentStatus: entity.EnrichmentDone,
+	}
+	if enrich {
+		song.EnrichmentStatus = entity.EnrichmentPending
+	}
+
+	for _, required := range []struct{ field, value string }{
+		{"group", song.GroupName},
+		{"song", song.SongName},
+	} {
+		if required.value == "" {
+			return nil, fmt.Errorf("field %q is required", required.field)
+		}
+		if utf8.RuneCountInString(required.value) > maxFieldLength {
+			return nil, fmt.Errorf("field %q must not exceed %d characters", required.field, maxFieldLength)
+		}
+	}
+
+	if date := strings.TrimSpace(row.ReleaseDate); date != "" {
+		releaseDate, err := time.Parse("02-01-2006", date)
+		if err != nil {
+			return nil, errors.New("field \"release_date\" must use the DD-MM-YYYY format")
+		}
+		song.ReleaseDate = releaseDate
+	}
+
+	if utf8.RuneCountInString(song.Link) > maxFieldLength {
+		return nil, fmt.Errorf("field \"link\" must not exceed %d characters", maxFieldLength)
+	}
+
+	song.Text = lyrics.Normalize(row.Text)
+	song.Sections = lyrics.Parse(song.Text)
+
+	return song, nil
+}
+
+// importKey normalises group and title the way the database compares them.
+func importKey(group, song string) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.Join(strings.Fields(s), " "))
+	}
+	return normalize(group) + "\x00" + normalize(song)
+}
+
+type importDecoder interface {
+	// next returns the next row. rowErr means only this row is unusable; err
+	// ends the import and is io.EOF at the end of the input.
+	next() (row dto.ImportSongRow, rowErr error, err error)
+}
+
+func newImportDecoder(r io.Reader, format ImportFormat) (importDecoder, error) {
+	switch format {
+	case ImportCSV:
+		return newCSVImportDecoder(r)
+	case ImportJSON:
+		return newJSONImportDecoder(r)
+	case ImportNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+		return &ndjsonImportDecoder{scanner: scanner}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidImport, format)
+	}
+}
+
+type csvImportDecoder struct {
+	reader  *csv.Reader
+	columns []string
+}
+
+func newCSVImportDecoder(r io.Reader) (*csvImportDecoder, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: CSV file is empty", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	columns := make([]string, len(header))
+	found := make(map[string]bool)
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "group", "song", "release_date", "text", "link":
+		default:
+			return nil, fmt.Errorf("%w: unknown CSV column %q", ErrInvalidImport, name)
+		}
+		if found[name] {
+			return nil, fmt.Errorf("%w: duplicate CSV column %q", ErrInvalidImport, name)
+		}
+		found[name] = true
+		columns[i] = name
+	}
+	if !found["group"] || !found["song"] {
+		return nil, fmt.Errorf("%w: CSV header must contain the group and song columns", ErrInvalidImport)
+	}
+
+	return &csvImportDecoder{reader: reader, columns: columns}, nil
+}
+
+func (d *csvImportDecoder) next() (dto.ImportSongRow, error, error) {
+	var row dto.ImportSongRow
+
+	record, err := d.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return row, parseErr.Err, nil
+		}
+		return row, nil, err
+	}
+	if len(record) != len(d.columns) {
+		return row, fmt.Errorf("row has %d fields, the header has %d", len(record), len(d.columns)), nil
+	}
+
+	for i, value := range record {
+		switch d.columns[i] {
+		case "group":
+			row.GroupName = value
+		case "song":
+			row.SongName = value
+		case "release_date":
+			row.ReleaseDate = value
+		case "text":
+			row.Text = value
+		case "link":
+			row.Link = value
+		}
+	}
+	return row, nil, nil
+}
+
+type jsonImportDecoder struct {
+	decoder *json.Decoder
+}
+
+func newJSONImportDecoder(r io.Reader) (*jsonImportDecoder, error) {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("%w: JSON import must be an array of songs", ErrInvalidImport)
+	}
+
+	return &jsonImportDecoder{decoder: decoder}, nil
+}
+
+func (d *jsonImportDecoder) next() (dto.ImportSongRow, error, error) {
+	var row dto.ImportSongRow
+
+	if !d.decoder.More() {
+		if _, err := d.decoder.Token(); err != nil {
+			return row, nil, err
+		}
+		return row, nil, io.EOF
+	}
+
+	var raw json.RawMessage
+	if err := d.decoder.Decode(&raw); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return row, nil, err
+	}
+	return row, decodeImportRow(raw, &row), nil
+}
+
+type ndjsonImportDecoder struct {
+	scanner *bufio.Scanner
+}
+
+func (d *ndjsonImportDecoder) next() (dto.ImportSongRow, error, error) {
+	var row dto.ImportSongRow
+
+	for d.scanner.Scan() {
+		line := bytes.TrimSpace(d.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		return row, decodeImportRow(line, &row), nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return row, nil, err
+	}
+	return row, nil, io.EOF
+}
+
+func decodeImportRow(data []byte, row *dto.ImportSongRow) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(row); err != nil {
+		return fmt.Errorf("invalid song: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/lyrics"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/jsonpatch"
+)
+
+// PatchFormat selects how Patch interprets the patch document.
+type PatchFormat int
+
+const (
+	MergePatch PatchFormat = iota
+	JSONPatch
+)
+
+// patchRetries bounds how often an unconditional patch is re-applied when the
+// song changes between reading and writing it.
+const patchRetries = 3
+
+const maxFieldLength = 255
+
+// Patch applies a merge patch or JSON Patch to the editable fields of a song:
+// group_name, song_name, release_date, text and link. Fields the patch does
+// not touch keep their stored values. A non-zero version makes the patch
+// conditional like Update; without one a concurrent edit makes the patch
+// apply again to the newer values.
+func (uc *SongUseCase) Patch(ctx context.Context, id int64, patch []byte, format PatchFormat, editor string, version int) (*dto.SongResponse, error) {
+	for attempt := 1; ; attempt++ {
+		current, err := uc.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error getting song: %w", err)
+		}
+		if version != 0 && current.Version != version {
+			return nil, repository.ErrSongVersionConflict
+		}
+
+		doc := patchDocument(current)
+		if format == JSONPatch {
+			doc, err = jsonpatch.Apply(doc, patch)
+		} else {
+			doc, err = jsonpatch.MergePatch(doc, patch)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		song, err := songFromPatchDocument(id, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		err = uc.repo.UpdateIfVersion(ctx, song, entity.RevisionInfo{Editor: editor}, current.Version)
+		if errors.Is(err, repository.ErrSongVersionConflict) && version == 0 && attempt < patchRetries {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error updating song: %w", err)
+		}
+
+		response := dto.ToSongResponse(song)
+		return &response, nil
+	}
+}
+
+func patchDocument(song *entity.Song) map[string]interface{} {
+	doc := map[string]interface{}{
+		"group_name": song.GroupName,
+		"song_name":  song.SongName,
+		"text":       song.Text,
+		"link":       song.Link,
+	}
+	if !song.ReleaseDate.IsZero() {
+		doc["release_date"] = dto.FormatReleaseDate(song.ReleaseDate)
+	}
+	return doc
+}
+
+func songFromPatchDocument(id int64, doc map[string]interface{}) (*entity.Song, error) {
+	song := &entity.Song{ID: id, EnrichmentStatus: entity.EnrichmentDone}
+
+	fields := make([]string, 0, len(doc))
+	for field := range doc {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		value := doc[field]
+		switch field {
+		case "group_name", "song_name", "release_date", "text", "link":
+		default:
+			return nil, fmt.Errorf("%w: field %q cannot be changed", ErrInvalidSongPatch, field)
+		}
+		if _, ok := value.(string); !ok && value != nil {
+			return nil, fmt.Errorf("%w: field %q must be a string", ErrInvalidSongPatch, field)
+		}
+	}
+
+	str := func(field string) string {
+		s, _ := doc[field].(string)
+		return s
+	}
+
+	song.GroupName = strings.TrimSpace(str("group_name"))
+	song.SongName = strings.TrimSpace(str("song_name"))
+	for _, required := range []struct{ field, value string }{
+		{"group_name", song.GroupName},
+		{"song_name", song.SongName},
+	} {
+		if required.value == "" {
+			return nil, fmt.Errorf("%w: field %q is required", ErrInvalidSongPatch, required.field)
+		}
+		if utf8.RuneCountInString(required.value) > maxFieldLength {
+			return nil, fmt.Errorf("%w: field %q must not exceed %d characters", ErrInvalidSongPatch, required.field, maxFieldLength)
+		}
+	}
+
+	if date := str("release_date"); date != "" {
+		releaseDate, err := time.Parse("02-01-2006", date)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field \"release_date\" must use the DD-MM-YYYY format", ErrInvalidSongPatch)
+		}
+		song.ReleaseDate = releaseDate
+	}
+
+	song.Link = strings.TrimSpace(str("link"))
+	if utf8.RuneCountInString(song.Link) > maxFieldLength {
+		return nil, fmt.Errorf("%w: field \"link\" must not exceed %d characters", ErrInvalidSongPatch, maxFieldLength)
+	}
+
+	song.Text = lyrics.Normalize(str("text"))
+	song.Sections = lyrics.Parse(song.Text)
+
+	return song, nil
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+)
+
+// suggestTimeout is the latency budget of a type-ahead lookup. A suggestion
+// that arrives later is of no use to the search box, so the queries are
+// cancelled instead of holding a connection.
+const suggestTimeout = 250 * time.Millisecond
+
+// Suggest completes a prefix to known group and song names for type-ahead
+// search. Case and accents are ignored, so "beyo" completes to "Beyoncé".
+func (uc *SongUseCase) Suggest(ctx context.Context, req *dto.SuggestRequest) (*dto.SuggestResponse, error) {
+	prefix := normalizePrefix(req.Query)
+	if prefix == "" {
+		return nil, fmt.Errorf("%w: q must not be blank", ErrInvalidFilter)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, suggestTimeout)
+	defer cancel()
+
+	completions, err := uc.repo.CompleteNames(ctx, &entity.NameCompletionQuery{
+		Prefix: prefix,
+		Limit:  req.Limit,
+		Groups: req.Type != "song",
+		Songs:  req.Type != "group",
+	})
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ErrSuggestTimeout
+		}
+		return nil, fmt.Errorf("error completing names: %w", err)
+	}
+
+	return &dto.SuggestResponse{
+		Query:  req.Query,
+		Groups: completions.GroupNames,
+		Songs:  completions.SongNames,
+	}, nil
+}
+
+// normalizePrefix collapses runs of whitespace like the stored names have
+// them, keeping one trailing space so that "the " does not complete to
+// "Theory".
+func normalizePrefix(query string) string {
+	prefix := strings.Join(strings.Fields(query), " ")
+	if prefix != "" && strings.TrimRight(query, " \t") != query {
+		prefix += " "
+	}
+	return prefix
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/pkg/logger"
+)
+
+// Trash lists the deleted songs that have not been purged yet, most recently
+// deleted first.
+func (uc *SongUseCase) Trash(ctx context.Context, req *dto.TrashListRequest) (*dto.TrashListResponse, error) {
+	songs, total, err := uc.repo.ListDeleted(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error listing deleted songs: %w", err)
+	}
+
+	response := &dto.TrashListResponse{
+		Songs:      make([]dto.SongResponse, 0, len(songs)),
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: (total + req.PageSize - 1) / req.PageSize,
+	}
+	for _, song := range songs {
+		response.Songs = append(response.Songs, dto.ToSongResponse(song))
+	}
+	return response, nil
+}
+
+// Restore takes a deleted song out of the trash with its lyrics, revisions
+// and references intact.
+func (uc *SongUseCase) Restore(ctx context.Context, id int64) (*dto.SongResponse, error) {
+	log := logger.New("debug")
+
+	song, err := uc.repo.Restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error restoring song: %w", err)
+	}
+
+	log.Info(ctx, "Song restored from trash", zap.Int64("id", id))
+
+	response := dto.ToSongResponse(song)
+	return &response, nil
+}
@@ -2,38 +2,38 @@ package usecase
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"time"
 
 	"go.uber.org/zap"
 
 	"song-library/internal/application/dto"
-	"song-library/internal/config"
 	"song-library/internal/domain/entity"
+	"song-library/internal/domain/lyrics"
 	"song-library/internal/domain/repository"
 	"song-library/pkg/logger"
 )
 
+// EnrichmentNotifier is told about songs waiting to be enriched so that the
+// background worker can pick them up without waiting for its next poll.
+type EnrichmentNotifier interface {
+	Notify()
+}
+
 type SongUseCase struct {
-	repo   repository.SongRepository
-	config *config.Config
+	repo         repository.SongRepository
+	translations repository.TranslationRepository
+	notifier     EnrichmentNotifier
 }
 
-func NewSongUseCase(repo repository.SongRepository, cfg *config.Config) *SongUseCase {
+func NewSongUseCase(repo repository.SongRepository, translations repository.TranslationRepository, notifier EnrichmentNotifier) *SongUseCase {
 	return &SongUseCase{
-		repo:   repo,
-		config: cfg,
+		repo:         repo,
+		translations: translations,
+		notifier:     notifier,
 	}
 }
 
-type MusicInfoResponse struct {
-	ReleaseDate string `json:"releaseDate"`
-	Text        string `json:"text"`
-	Link        string `json:"link"`
-}
-
 func (uc *SongUseCase) Create(ctx context.Context, req *dto.CreateSongRequest) (*dto.SongResponse, error) {
 	log := logger.New("debug")
 	
@@ -41,26 +41,10 @@ func (uc *SongUseCase) Create(ctx context.Context, req *dto.CreateSongRequest) (
 		zap.String("group", req.GroupName),
 		zap.String("song", req.SongName))
 
-	musicInfo, err := uc.fetchMusicInfo(req.GroupName, req.SongName)
-	if err != nil {
-		log.Error(ctx, "Error getting song information", zap.Error(err))
-		return nil, fmt.Errorf("error getting song information: %w", err)
-	}
-
-	releaseDate, err := time.Parse("02-01-2006", musicInfo.ReleaseDate)
-	if err != nil {
-		log.Error(ctx, "Error parsing release date", 
-			zap.Error(err),
-			zap.String("date", musicInfo.ReleaseDate))
-		return nil, fmt.Errorf("error parsing release date: %w", err)
-	}
-
 	song := &entity.Song{
-		GroupName:   req.GroupName,
-		SongName:    req.SongName,
-		ReleaseDate: releaseDate,
-		Text:        musicInfo.Text,
-		Link:        musicInfo.Link,
+		GroupName:        req.GroupName,
+		SongName:         req.SongName,
+		EnrichmentStatus: entity.EnrichmentPending,
 	}
 
 	if err := uc.repo.Create(ctx, song); err != nil {
@@ -68,22 +52,19 @@ func (uc *SongUseCase) Create(ctx context.Context, req *dto.CreateSongRequest) (
 		return nil, fmt.Errorf("error creating song: %w", err)
 	}
 
-	response := &dto.SongResponse{
-		ID:          song.ID,
-		GroupName:   song.GroupName,
-		SongName:    song.SongName,
-		ReleaseDate: song.ReleaseDate.Format("02-01-2006"),
-		Text:        song.Text,
-		Link:        song.Link,
-		CreatedAt:   song.CreatedAt,
-		UpdatedAt:   song.UpdatedAt,
+	if uc.notifier != nil {
+		uc.notifier.Notify()
 	}
 
-	log.Info(ctx, "Song successfully created", zap.Int64("id", song.ID))
-	return response, nil
+	response := dto.ToSongResponse(song)
+
+	log.Info(ctx, "Song accepted for enrichment", zap.Int64("id", song.ID))
+	return &response, nil
 }
 
-func (uc *SongUseCase) Update(ctx context.Context, id int64, req *dto.UpdateSongRequest) (*dto.SongResponse, error) {
+// Update replaces the song. A non-zero version makes the update conditional
+// on the song not having changed since that version was read.
+func (uc *SongUseCase) Update(ctx context.Context, id int64, req *dto.UpdateSongRequest, editor string, version int) (*dto.SongResponse, error) {
 	log := logger.New("debug")
 	
 	log.Debug(ctx, "Starting song update", zap.Int64("id", id))
@@ -96,36 +77,44 @@ func (uc *SongUseCase) Update(ctx context.Context, id int64, req *dto.UpdateSong
 		return nil, fmt.Errorf("error parsing release date: %w", err)
 	}
 
+	text := lyrics.Normalize(req.Text)
 	song := &entity.Song{
-		ID:          id,
-		GroupName:   req.GroupName,
-		SongName:    req.SongName,
-		ReleaseDate: releaseDate,
-		Text:        req.Text,
-		Link:        req.Link,
+		ID:               id,
+		GroupName:        req.GroupName,
+		SongName:         req.SongName,
+		ReleaseDate:      releaseDate,
+		Text:             text,
+		Sections:         lyrics.Parse(text),
+		Link:             req.Link,
+		EnrichmentStatus: entity.EnrichmentDone,
 	}
 
-	if err := uc.repo.Update(ctx, song); err != nil {
+	info := entity.RevisionInfo{Editor: editor}
+	if version != 0 {
+		err = uc.repo.UpdateIfVersion(ctx, song, info, version)
+	} else {
+		err = uc.repo.Update(ctx, song, info)
+	}
+	if err != nil {
 		log.Error(ctx, "Error updating song", zap.Error(err))
 		return nil, fmt.Errorf("error updating song: %w", err)
 	}
 
-	response := &dto.SongResponse{
-		ID:          song.ID,
-		GroupName:   song.GroupName,
-		SongName:    song.SongName,
-		ReleaseDate: song.ReleaseDate.Format("02-01-2006"),
-		Text:        song.Text,
-		Link:        song.Link,
-		UpdatedAt:   song.UpdatedAt,
-	}
+	response := dto.ToSongResponse(song)
 
 	log.Info(ctx, "Song successfully updated", zap.Int64("id", id))
-	return response, nil
+	return &response, nil
 }
 
-func (uc *SongUseCase) Delete(ctx context.Context, id int64) error {
-	if err := uc.repo.Delete(ctx, id); err != nil {
+// Delete removes the song; a non-zero version makes it conditional like Update.
+func (uc *SongUseCase) Delete(ctx context.Context, id int64, version int) error {
+	var err error
+	if version != 0 {
+		err = uc.repo.DeleteIfVersion(ctx, id, version)
+	} else {
+		err = uc.repo.Delete(ctx, id)
+	}
+	if err != nil {
 		return fmt.Errorf("error deleting song: %w", err)
 	}
 	return nil
@@ -142,107 +131,575 @@ func (uc *SongUseCase) Get(ctx context.Context, id int64) (*dto.SongResponse, er
 		return nil, fmt.Errorf("error getting song: %w", err)
 	}
 
-	response := &dto.SongResponse{
-		ID:          song.ID,
-		GroupName:   song.GroupName,
-		SongName:    song.SongName,
-		ReleaseDate: song.ReleaseDate.Format("02-01-2006"),
-		Text:        song.Text,
-		Link:        song.Link,
-		CreatedAt:   song.CreatedAt,
-		UpdatedAt:   song.UpdatedAt,
-	}
+	response := dto.ToSongResponse(song)
 
 	log.Debug(ctx, "Song successfully retrieved", zap.Int64("id", id))
+	return &response, nil
+}
+
+// GetSongText pages through the song's lyrics section by section. Repeated
+// choruses are collapsed to a marker unless req.Expand is set, and req.Type
+// restricts the result to one kind of section. With req.Lang the translation
+// is paged instead; req.SideBySide pairs it with the original section by
+// section.
+func (uc *SongUseCase) GetSongText(ctx context.Context, id int64, req *dto.GetSongTextRequest) (*dto.SongTextResponse, error) {
+	songLyrics, err := uc.repo.GetLyrics(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting song text: %w", err)
+	}
+
+	original := songLyrics.Sections
+	if len(original) == 0 {
+		original = lyrics.Parse(songLyrics.Text)
+	}
+	original = selectSections(original, req)
+
+	response := &dto.SongTextResponse{
+		ID:        songLyrics.ID,
+		GroupName: songLyrics.GroupName,
+		SongName:  songLyrics.SongName,
+		Page:      req.Page,
+		PageSize:  req.PageSize,
+	}
+
+	sections := original
+	total := len(sections)
+	if req.Lang != "" {
+		lang, err := parseLanguage(req.Lang)
+		if err != nil {
+			return nil, err
+		}
+
+		translation, err := uc.translations.Get(ctx, id, lang)
+		if err != nil {
+			return nil, fmt.Errorf("error getting translation: %w", err)
+		}
+
+		sections = selectSections(lyrics.Parse(translation.Text), req)
+		total = len(sections)
+		if req.SideBySide && len(original) > total {
+			total = len(original)
+		}
+		response.Language = lang
+	}
+
+	start, end := pageBounds(total, req.Page, req.PageSize)
+
+	response.Verses = []string{}
+	response.Sections = []dto.SongSectionResponse{}
+	for i := start; i < end; i++ {
+		if req.SideBySide {
+			response.Pairs = append(response.Pairs, dto.SongTextPair{
+				Original:    renderSection(original, i),
+				Translation: renderSection(sections, i),
+			})
+		}
+		if i >= len(sections) {
+			continue
+		}
+		section := sections[i]
+		response.Verses = append(response.Verses, lyrics.Render(section))
+		response.Sections = append(response.Sections, dto.SongSectionResponse{
+			Type:    string(section.Type),
+			Ordinal: section.Ordinal,
+			Lines:   section.Lines,
+			Repeat:  section.Repeat,
+		})
+	}
+
+	response.TotalVerses = total
+	response.TotalPages = (total + req.PageSize - 1) / req.PageSize
 	return response, nil
 }
 
-func (uc *SongUseCase) fetchMusicInfo(group, song string) (*MusicInfoResponse, error) {
-	log := logger.New("debug")
-	ctx := context.Background()
-	
-	url := fmt.Sprintf("%s/info?group=%s&song=%s", uc.config.API.MusicInfoURL, group, song)
-	log.Debug(ctx, "Sending request to external API", 
-		zap.String("url", url),
-		zap.String("group", group),
-		zap.String("song", song))
-	
-	resp, err := http.Get(url)
+func selectSections(sections []entity.SongSection, req *dto.GetSongTextRequest) []entity.SongSection {
+	if req.Expand {
+		sections = lyrics.Expand(sections)
+	}
+	if req.Type != "" {
+		sections = filterSections(sections, entity.SectionType(req.Type), req.Expand)
+	}
+	return sections
+}
+
+func renderSection(sections []entity.SongSection, i int) string {
+	if i >= len(sections) {
+		return ""
+	}
+	return lyrics.Render(sections[i])
+}
+
+func pageBounds(total, page, pageSize int) (int, int) {
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// BackfillSections parses the plain-text lyrics of songs stored before
+// structured sections existed. It returns the number of songs processed.
+func (uc *SongUseCase) BackfillSections(ctx context.Context, batchSize int) (int, error) {
+	var processed int
+	var afterID int64
+
+	for {
+		batch, err := uc.repo.ListWithoutSections(ctx, afterID, batchSize)
+		if err != nil {
+			return processed, fmt.Errorf("error listing songs without sections: %w", err)
+		}
+		if len(batch) == 0 {
+			return processed, nil
+		}
+
+		for _, song := range batch {
+			if err := uc.repo.SaveSections(ctx, song.ID, lyrics.Parse(song.Text)); err != nil {
+				return processed, fmt.Errorf("error saving sections of song %d: %w", song.ID, err)
+			}
+			afterID = song.ID
+			processed++
+		}
+	}
+}
+
+// ImportLRC parses an LRC file and replaces the song's synced lyrics with it.
+// The plain text of the song is left untouched.
+func (uc *SongUseCase) ImportLRC(ctx context.Context, id int64, data string) (*dto.SyncedLyricsResponse, error) {
+	lines, err := lyrics.ParseLRC(data)
 	if err != nil {
-		log.Error(ctx, "Error sending API request", zap.Error(err))
-		return nil, fmt.Errorf("error sending API request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		log.Error(ctx, "API returned unexpected status", 
-			zap.Int("status_code", resp.StatusCode))
-		return nil, fmt.Errorf("API returned status: %d", resp.StatusCode)
+	if err := uc.repo.SaveSyncedLyrics(ctx, id, lines); err != nil {
+		return nil, fmt.Errorf("error saving synced lyrics: %w", err)
 	}
 
-	var musicInfo MusicInfoResponse
-	if err := json.NewDecoder(resp.Body).Decode(&musicInfo); err != nil {
-		log.Error(ctx, "Error decoding response", zap.Error(err))
-		return nil, fmt.Errorf("error decoding response: %w", err)
+	return toSyncedLyricsResponse(id, lines), nil
+}
+
+func (uc *SongUseCase) ExportLRC(ctx context.Context, id int64) (string, error) {
+	song, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("error getting song: %w", err)
+	}
+
+	lines, err := uc.repo.GetSyncedLyrics(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("error getting synced lyrics: %w", err)
 	}
 
-	log.Debug(ctx, "Successfully retrieved song information", 
-		zap.Any("music_info", musicInfo))
-	return &musicInfo, nil
+	return lyrics.FormatLRC(song.GroupName, song.SongName, lines), nil
 }
 
-func (uc *SongUseCase) GetSongText(ctx context.Context, id int64, req *dto.GetSongTextRequest) (*dto.SongTextResponse, error) {
-	songText, err := uc.repo.GetSongTextByVerses(ctx, id, req.Page, req.PageSize)
+func (uc *SongUseCase) DeleteSyncedLyrics(ctx context.Context, id int64) error {
+	if err := uc.repo.DeleteSyncedLyrics(ctx, id); err != nil {
+		return fmt.Errorf("error deleting synced lyrics: %w", err)
+	}
+	return nil
+}
+
+// GetActiveLine returns the line being sung at the given playback position
+// together with the line that follows it.
+func (uc *SongUseCase) GetActiveLine(ctx context.Context, id int64, req *dto.ActiveLineRequest) (*dto.ActiveLineResponse, error) {
+	lines, err := uc.repo.GetSyncedLyrics(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("error getting song text: %w", err)
+		return nil, fmt.Errorf("error getting synced lyrics: %w", err)
 	}
 
-	totalPages := (songText.TotalVerses + req.PageSize - 1) / req.PageSize
+	response := &dto.ActiveLineResponse{ID: id, AtMs: req.At}
 
-	return &dto.SongTextResponse{
-		ID:          songText.ID,
-		GroupName:   songText.GroupName,
-		SongName:    songText.SongName,
-		Verses:      songText.Verses,
-		TotalVerses: songText.TotalVerses,
-		Page:        songText.Page,
-		PageSize:    songText.PageSize,
-		TotalPages:  totalPages,
-	}, nil
+	index := lyrics.ActiveLine(lines, time.Duration(req.At)*time.Millisecond)
+	if index >= 0 && lines[index].Text != "" {
+		line := dto.ToSyncedLineResponse(lines, index)
+		response.Line = &line
+	}
+	if index+1 < len(lines) {
+		next := dto.ToSyncedLineResponse(lines, index+1)
+		response.Next = &next
+	}
+
+	return response, nil
 }
 
+func toSyncedLyricsResponse(id int64, lines []entity.SyncedLine) *dto.SyncedLyricsResponse {
+	response := &dto.SyncedLyricsResponse{
+		ID:         id,
+		Lines:      make([]dto.SyncedLineResponse, 0, len(lines)),
+		TotalLines: len(lines),
+	}
+	for i := range lines {
+		response.Lines = append(response.Lines, dto.ToSyncedLineResponse(lines, i))
+	}
+	return response
+}
+
+func (uc *SongUseCase) ListRevisions(ctx context.Context, id int64, req *dto.RevisionListRequest) (*dto.RevisionListResponse, error) {
+	revisions, total, err := uc.repo.ListRevisions(ctx, id, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error listing revisions: %w", err)
+	}
+
+	response := &dto.RevisionListResponse{
+		SongID:     id,
+		Revisions:  make([]dto.RevisionResponse, 0, len(revisions)),
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: (total + req.PageSize - 1) / req.PageSize,
+	}
+	for _, revision := range revisions {
+		response.Revisions = append(response.Revisions, dto.ToRevisionResponse(revision))
+	}
+	return response, nil
+}
+
+func (uc *SongUseCase) GetRevision(ctx context.Context, id int64, revision int) (*dto.RevisionResponse, error) {
+	result, err := uc.repo.GetRevision(ctx, id, revision)
+	if err != nil {
+		return nil, fmt.Errorf("error getting revision: %w", err)
+	}
+
+	response := dto.ToRevisionResponse(result)
+	return &response, nil
+}
+
+// DiffRevisions compares two revisions field by field and the lyrics line by
+// line. Without req.To the older revision is compared with the current song.
+func (uc *SongUseCase) DiffRevisions(ctx context.Context, id int64, req *dto.RevisionDiffRequest) (*dto.RevisionDiffResponse, error) {
+	from, err := uc.repo.GetRevision(ctx, id, req.From)
+	if err != nil {
+		return nil, fmt.Errorf("error getting revision: %w", err)
+	}
+
+	var to *entity.SongRevision
+	if req.To != 0 {
+		to, err = uc.repo.GetRevision(ctx, id, req.To)
+		if err != nil {
+			return nil, fmt.Errorf("error getting revision: %w", err)
+		}
+	} else {
+		song, err := uc.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error getting song: %w", err)
+		}
+		to = &entity.SongRevision{
+			SongID:      song.ID,
+			GroupName:   song.GroupName,
+			SongName:    song.SongName,
+			ReleaseDate: song.ReleaseDate,
+			Text:        song.Text,
+			Link:        song.Link,
+		}
+	}
+
+	response := &dto.RevisionDiffResponse{
+		SongID: id,
+		From:   req.From,
+		Fields: []dto.FieldChange{},
+		Lines:  []dto.LineChange{},
+	}
+	if req.To != 0 {
+		response.To = &req.To
+	}
+
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"group_name", from.GroupName, to.GroupName},
+		{"song_name", from.SongName, to.SongName},
+		{"release_date", dto.FormatReleaseDate(from.ReleaseDate), dto.FormatReleaseDate(to.ReleaseDate)},
+		{"link", from.Link, to.Link},
+	}
+	for _, field := range fields {
+		if field.from != field.to {
+			response.Fields = append(response.Fields, dto.FieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+
+	response.TextChanged = from.Text != to.Text
+	for _, line := range lyrics.DiffLines(from.Text, to.Text) {
+		response.Lines = append(response.Lines, dto.LineChange{
+			Op:      string(line.Op),
+			Text:    line.Text,
+			OldLine: line.OldLine,
+			NewLine: line.NewLine,
+		})
+	}
+
+	return response, nil
+}
+
+// RestoreRevision brings back the values of an old revision. The values it
+// replaces are kept as a new revision, so a restore can be undone as well.
+func (uc *SongUseCase) RestoreRevision(ctx context.Context, id int64, revision int, editor string) (*dto.SongResponse, error) {
+	old, err := uc.repo.GetRevision(ctx, id, revision)
+	if err != nil {
+		return nil, fmt.Errorf("error getting revision: %w", err)
+	}
+
+	text := lyrics.Normalize(old.Text)
+	song := &entity.Song{
+		ID:               id,
+		GroupName:        old.GroupName,
+		SongName:         old.SongName,
+		ReleaseDate:      old.ReleaseDate,
+		Text:             text,
+		Sections:         lyrics.Parse(text),
+		Link:             old.Link,
+		EnrichmentStatus: entity.EnrichmentDone,
+	}
+
+	info := entity.RevisionInfo{Editor: editor, RestoredFrom: revision}
+	if err := uc.repo.Update(ctx, song, info); err != nil {
+		return nil, fmt.Errorf("error restoring revision: %w", err)
+	}
+
+	response := dto.ToSongResponse(song)
+	return &response, nil
+}
+
+func filterSections(sections []entity.SongSection, typ entity.SectionType, includeRepeats bool) []entity.SongSection {
+	var filtered []entity.SongSection
+	for _, section := range sections {
+		if section.Type != typ || (section.Repeat && !includeRepeats) {
+			continue
+		}
+		filtered = append(filtered, section)
+	}
+	return filtered
+}
+
+// List returns a page of songs. Without req.Cursor pages are numbered; a
+// cursor taken from next_cursor or prev_cursor of an earlier response
+// continues from that point by keyset instead, which stays fast on deep pages
+// and does not shift when songs are added.
 func (uc *SongUseCase) List(ctx context.Context, req *dto.SongListRequest) (*dto.SongListResponse, error) {
+	if req.Page < 1 || req.PageSize < 1 {
+		return nil, fmt.Errorf("%w: page and page_size must be positive", ErrInvalidFilter)
+	}
+
+	filter, err := songFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	sortOrder := formatSongSort(filter.Sort)
+	if req.Cursor != "" {
+		if filter.Cursor, err = decodeSongCursor(req.Cursor, sortOrder); err != nil {
+			return nil, err
+		}
+	}
+	filter.Lookahead = true
+	filter.SkipTotal = !req.WithTotal
+
+	songs, total, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting song list: %w", err)
+	}
+
+	backward := filter.Cursor != nil && filter.Cursor.Backward
+	hasMore := len(songs) > req.PageSize
+	if hasMore {
+		if backward {
+			songs = songs[len(songs)-req.PageSize:]
+		} else {
+			songs = songs[:req.PageSize]
+		}
+	}
+
+	response := &dto.SongListResponse{
+		Songs:    make([]dto.SongResponse, 0, len(songs)),
+		PageSize: req.PageSize,
+	}
+	for _, song := range songs {
+		response.Songs = append(response.Songs, dto.ToSongResponse(song))
+	}
+
+	if filter.Cursor == nil {
+		response.Page = req.Page
+	}
+	if req.WithTotal {
+		totalPages := (total + req.PageSize - 1) / req.PageSize
+		response.Total = &total
+		response.TotalPages = &totalPages
+	}
+
+	if len(songs) > 0 {
+		first, last := songs[0], songs[len(songs)-1]
+		// Going forward there is a previous page unless this is the first
+		// one; going backward there is always a next page.
+		if hasMore || backward {
+			response.NextCursor = encodeSongCursor(entity.SongCursor{
+				ID:   last.ID,
+				Sort: sortOrder,
+				Keys: last.SortKey,
+			})
+		}
+		if (backward && hasMore) || (!backward && (filter.Cursor != nil || req.Page > 1)) {
+			response.PrevCursor = encodeSongCursor(entity.SongCursor{
+				ID:       first.ID,
+				Sort:     sortOrder,
+				Keys:     first.SortKey,
+				Backward: true,
+			})
+		}
+	}
+
+	if len(songs) == 0 && filter.Cursor == nil && req.Page <= 1 {
+		response.DidYouMean = uc.suggestNames(ctx, filter)
+	}
+
+	return response, nil
+}
+
+// suggestNames looks for known names close to the name filters of a list
+// that came back empty. Fuzzy filters already tolerate typos, so they get no
+// suggestion. A failed lookup only costs the suggestion.
+func (uc *SongUseCase) suggestNames(ctx context.Context, filter *entity.SongFilter) *dto.NameSuggestion {
+	if filter.NameMatch == entity.MatchFuzzy || (filter.GroupName == "" && filter.SongName == "") {
+		return nil
+	}
+
+	suggestion, err := uc.repo.SuggestNames(ctx, filter.GroupName, filter.SongName)
+	if err != nil {
+		logger.New("debug").Warn(ctx, "Failed to suggest song names", zap.Error(err))
+		return nil
+	}
+	if suggestion.GroupName == "" && suggestion.SongName == "" {
+		return nil
+	}
+	return &dto.NameSuggestion{
+		GroupName: suggestion.GroupName,
+		SongName:  suggestion.SongName,
+	}
+}
+
+func songFilter(req *dto.SongListRequest) (*entity.SongFilter, error) {
 	filter := &entity.SongFilter{
-		GroupName: req.GroupName,
-		SongName:  req.SongName,
-		Text:      req.Text,
-		Link:      req.Link,
-		Page:      req.Page,
-		PageSize:  req.PageSize,
+		ArtistID:         req.ArtistID,
+		GroupName:        req.GroupName,
+		SongName:         req.SongName,
+		NameMatch:        entity.NameMatch(req.NameMatch),
+		Text:             req.Text,
+		Link:             req.Link,
+		HasLyrics:        req.HasLyrics,
+		HasLink:          req.HasLink,
+		MinAverageRating: req.MinAverageRating,
+		MinRatingCount:   req.MinRatingCount,
+		MinFavoriteCount: req.MinFavoriteCount,
+		MinPlayCount:     req.MinPlayCount,
+		Sort:             parseSongSort(req.Sort),
+		Page:             req.Page,
+		PageSize:         req.PageSize,
+	}
+
+	if filter.NameMatch == entity.MatchFuzzy {
+		filter.MinSimilarity = req.MinSimilarity
+		// Without an explicit order fuzzy matches are ranked best first.
+		if len(filter.Sort) == 0 && (req.GroupName != "" || req.SongName != "") {
+			filter.Sort = []entity.SongSort{{Field: entity.SortSimilarity, Desc: true}}
+		}
 	}
 
 	if req.ReleaseDate != "" {
 		releaseDate, err := time.Parse("2006-01-02", req.ReleaseDate)
 		if err != nil {
-			return nil, fmt.Errorf("invalid release date format: %w", err)
+			return nil, fmt.Errorf("%w: release_date must use the YYYY-MM-DD format", ErrInvalidFilter)
 		}
 		filter.ReleaseDate = releaseDate
 	}
 
-	songs, total, err := uc.repo.List(ctx, filter)
+	// released_from/released_to, year and decade all narrow the same range.
+	narrow := func(from, before time.Time) {
+		if !from.IsZero() && from.After(filter.ReleasedFrom) {
+			filter.ReleasedFrom = from
+		}
+		if !before.IsZero() && (filter.ReleasedBefore.IsZero() || before.Before(filter.ReleasedBefore)) {
+			filter.ReleasedBefore = before
+		}
+	}
+	if req.ReleasedFrom != "" {
+		from, err := time.Parse("2006-01-02", req.ReleasedFrom)
+		if err != nil {
+			return nil, fmt.Errorf("%w: released_from must use the YYYY-MM-DD format", ErrInvalidFilter)
+		}
+		narrow(from, time.Time{})
+	}
+	if req.ReleasedTo != "" {
+		to, err := time.Parse("2006-01-02", req.ReleasedTo)
+		if err != nil {
+			return nil, fmt.Errorf("%w: released_to must use the YYYY-MM-DD format", ErrInvalidFilter)
+		}
+		narrow(time.Time{}, to.AddDate(0, 0, 1))
+	}
+	if req.Year != 0 {
+		from := time.Date(req.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		narrow(from, from.AddDate(1, 0, 0))
+	}
+	if req.Decade != 0 {
+		if req.Decade%10 != 0 {
+			return nil, fmt.Errorf("%w: decade must be a multiple of 10 such as 1990", ErrInvalidFilter)
+		}
+		from := time.Date(req.Decade, time.January, 1, 0, 0, 0, 0, time.UTC)
+		narrow(from, from.AddDate(10, 0, 0))
+	}
+
+	for _, bound := range []struct {
+		name   string
+		value  string
+		target *time.Time
+	}{
+		{"created_from", req.CreatedFrom, &filter.CreatedFrom},
+		{"created_to", req.CreatedTo, &filter.CreatedTo},
+		{"updated_from", req.UpdatedFrom, &filter.UpdatedFrom},
+		{"updated_to", req.UpdatedTo, &filter.UpdatedTo},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		value, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s must be an RFC 3339 timestamp", ErrInvalidFilter, bound.name)
+		}
+		*bound.target = value
+	}
+
+	return filter, nil
+}
+
+func (uc *SongUseCase) Search(ctx context.Context, req *dto.SongSearchRequest) (*dto.SongSearchResponse, error) {
+	query := &entity.SongSearchQuery{
+		Query:    req.Query,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	results, total, err := uc.repo.Search(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("error getting song list: %w", err)
+		return nil, fmt.Errorf("error searching songs: %w", err)
 	}
 
 	totalPages := (total + req.PageSize - 1) / req.PageSize
 
-	var songResponses []dto.SongResponse
-	for _, song := range songs {
-		songResponses = append(songResponses, dto.ToSongResponse(song))
+	hits := make([]dto.SongSearchHit, 0, len(results))
+	for _, result := range results {
+		hits = append(hits, dto.SongSearchHit{
+			ID:          result.Song.ID,
+			GroupName:   result.Song.GroupName,
+			SongName:    result.Song.SongName,
+			ReleaseDate: dto.FormatReleaseDate(result.Song.ReleaseDate),
+			Link:        result.Song.Link,
+			Rank:        result.Rank,
+			Snippet:     result.Snippet,
+		})
 	}
 
-	return &dto.SongListResponse{
-		Songs:      songResponses,
+	return &dto.SongSearchResponse{
+		Results:    hits,
 		Total:      total,
 		Page:       req.Page,
 		PageSize:   req.PageSize,
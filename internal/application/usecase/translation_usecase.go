@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/text/language"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/lyrics"
+	"song-library/internal/domain/repository"
+)
+
+type TranslationUseCase struct {
+	repo repository.TranslationRepository
+}
+
+func NewTranslationUseCase(repo repository.TranslationRepository) *TranslationUseCase {
+	return &TranslationUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *TranslationUseCase) Create(ctx context.Context, songID int64, req *dto.CreateTranslationRequest) (*dto.TranslationResponse, error) {
+	lang, err := parseLanguage(req.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	translation := &entity.SongTranslation{
+		SongID:   songID,
+		Language: lang,
+		Text:     lyrics.Normalize(req.Text),
+	}
+	if err := uc.repo.Create(ctx, translation); err != nil {
+		return nil, fmt.Errorf("error creating translation: %w", err)
+	}
+
+	response := dto.ToTranslationResponse(translation)
+	return &response, nil
+}
+
+func (uc *TranslationUseCase) Update(ctx context.Context, songID int64, lang string, req *dto.UpdateTranslationRequest) (*dto.TranslationResponse, error) {
+	lang, err := parseLanguage(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	translation := &entity.SongTranslation{
+		SongID:   songID,
+		Language: lang,
+		Text:     lyrics.Normalize(req.Text),
+	}
+	if err := uc.repo.Update(ctx, translation); err != nil {
+		return nil, fmt.Errorf("error updating translation: %w", err)
+	}
+
+	response := dto.ToTranslationResponse(translation)
+	return &response, nil
+}
+
+func (uc *TranslationUseCase) Delete(ctx context.Context, songID int64, lang string) error {
+	lang, err := parseLanguage(lang)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(ctx, songID, lang); err != nil {
+		return fmt.Errorf("error deleting translation: %w", err)
+	}
+	return nil
+}
+
+func (uc *TranslationUseCase) Get(ctx context.Context, songID int64, lang string) (*dto.TranslationResponse, error) {
+	lang, err := parseLanguage(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	translation, err := uc.repo.Get(ctx, songID, lang)
+	if err != nil {
+		return nil, fmt.Errorf("error getting translation: %w", err)
+	}
+
+	response := dto.ToTranslationResponse(translation)
+	return &response, nil
+}
+
+func (uc *TranslationUseCase) List(ctx context.Context, songID int64) (*dto.TranslationListResponse, error) {
+	translations, err := uc.repo.List(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing translations: %w", err)
+	}
+
+	response := &dto.TranslationListResponse{
+		SongID:       songID,
+		Translations: make([]dto.TranslationResponse, 0, len(translations)),
+	}
+	for _, translation := range translations {
+		response.Translations = append(response.Translations, dto.ToTranslationResponse(translation))
+	}
+	return response, nil
+}
+
+// parseLanguage validates a BCP-47 tag and returns its canonical form, so
+// that "pt-br" and "pt-BR" address the same translation.
+func parseLanguage(tag string) (string, error) {
+	parsed, err := language.Parse(tag)
+	if err != nil || parsed == language.Und {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLanguage, tag)
+	}
+	return parsed.String(), nil
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/config"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/jwt"
+	"song-library/pkg/logger"
+)
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// maxPasswordBytes is the longest password bcrypt accepts. The request
+// binding counts characters, so multi-byte passwords are checked here.
+const maxPasswordBytes = 72
+
+// accessClaims are the claims of the access tokens issued to users. The
+// authenticator reads the subject and role like those of any other JWT.
+type accessClaims struct {
+	jwt.RegisteredClaims
+	Role   string `json:"role"`
+	UserID int64  `json:"uid"`
+}
+
+type UserUseCase struct {
+	repo repository.UserRepository
+	cfg  config.AuthConfig
+	// dummyHash is compared against when a username does not exist, so that
+	// a failed login takes as long for unknown users as for wrong passwords.
+	dummyHash []byte
+}
+
+func NewUserUseCase(repo repository.UserRepository, cfg config.AuthConfig) *UserUseCase {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("song-library"), bcrypt.DefaultCost)
+	return &UserUseCase{
+		repo:      repo,
+		cfg:       cfg,
+		dummyHash: dummyHash,
+	}
+}
+
+// Register creates a reader account and logs it in. Higher roles are granted
+// by an admin.
+func (uc *UserUseCase) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.TokenResponse, error) {
+	if uc.cfg.JWT.HS256Secret == "" {
+		return nil, ErrTokenIssuerDisabled
+	}
+
+	username := strings.TrimSpace(req.Username)
+	if !usernamePattern.MatchString(username) {
+		return nil, ErrInvalidUsername
+	}
+	if len(req.Password) > maxPasswordBytes {
+		return nil, ErrPasswordTooLong
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
+	}
+
+	user := &entity.User{
+		Username:     username,
+		PasswordHash: string(hash),
+		Role:         entity.RoleReader,
+	}
+	if err := uc.repo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("error creating user: %w", err)
+	}
+
+	return uc.startSession(ctx, user)
+}
+
+func (uc *UserUseCase) Login(ctx context.Context, req *dto.LoginRequest) (*dto.TokenResponse, error) {
+	log := logger.New("debug")
+
+	if uc.cfg.JWT.HS256Secret == "" {
+		return nil, ErrTokenIssuerDisabled
+	}
+
+	user, err := uc.repo.GetByUsername(ctx, strings.TrimSpace(req.Username))
+	if errors.Is(err, repository.ErrUserNotFound) {
+		_ = bcrypt.CompareHashAndPassword(uc.dummyHash, []byte(req.Password))
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		log.Warn(ctx, "Login with wrong password", zap.Int64("user_id", user.ID))
+		return nil, ErrInvalidCredentials
+	}
+	if user.Disabled() {
+		return nil, repository.ErrUserDisabled
+	}
+
+	return uc.startSession(ctx, user)
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token. The
+// access token carries the user's current role.
+func (uc *UserUseCase) Refresh(ctx context.Context, req *dto.RefreshRequest) (*dto.TokenResponse, error) {
+	if uc.cfg.JWT.HS256Secret == "" {
+		return nil, ErrTokenIssuerDisabled
+	}
+
+	next, raw, err := uc.newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.repo.RotateRefreshToken(ctx, hashRefreshToken(req.RefreshToken), next)
+	if err != nil {
+		return nil, fmt.Errorf("error rotating refresh token: %w", err)
+	}
+
+	return uc.tokenResponse(user, raw)
+}
+
+// Logout revokes the refresh token and every token rotated from the same
+// login. Access tokens stay valid until they expire.
+func (uc *UserUseCase) Logout(ctx context.Context, req *dto.RefreshRequest) error {
+	if err := uc.repo.RevokeRefreshToken(ctx, hashRefreshToken(req.RefreshToken)); err != nil {
+		return fmt.Errorf("error revoking refresh token: %w", err)
+	}
+	return nil
+}
+
+func (uc *UserUseCase) List(ctx context.Context, req *dto.UserListRequest) (*dto.UserListResponse, error) {
+	filter := &entity.UserFilter{
+		Username: req.Username,
+		Role:     entity.Role(req.Role),
+		Disabled: req.Disabled,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	users, total, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user list: %w", err)
+	}
+
+	userResponses := make([]dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, dto.ToUserResponse(user))
+	}
+
+	return &dto.UserListResponse{
+		Users:      userResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: (total + req.PageSize - 1) / req.PageSize,
+	}, nil
+}
+
+// UpdateRole takes effect when the user next logs in or refreshes; access
+// tokens already issued keep their role until they expire.
+func (uc *UserUseCase) UpdateRole(ctx context.Context, id int64, req *dto.UpdateUserRoleRequest) (*dto.UserResponse, error) {
+	role, err := entity.ParseRole(req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.repo.UpdateRole(ctx, id, role)
+	if err != nil {
+		return nil, fmt.Errorf("error updating user role: %w", err)
+	}
+
+	response := dto.ToUserResponse(user)
+	return &response, nil
+}
+
+func (uc *UserUseCase) SetDisabled(ctx context.Context, id int64, disabled bool) (*dto.UserResponse, error) {
+	user, err := uc.repo.SetDisabled(ctx, id, disabled)
+	if err != nil {
+		return nil, fmt.Errorf("error updating user: %w", err)
+	}
+
+	response := dto.ToUserResponse(user)
+	return &response, nil
+}
+
+func (uc *UserUseCase) startSession(ctx context.Context, user *entity.User) (*dto.TokenResponse, error) {
+	token, raw, err := uc.newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	token.UserID = user.ID
+
+	family := make([]byte, 16)
+	if _, err := rand.Read(family); err != nil {
+		return nil, fmt.Errorf("error generating token family: %w", err)
+	}
+	token.Family = hex.EncodeToString(family)
+
+	if err := uc.repo.CreateRefreshToken(ctx, token); err != nil {
+		return nil, fmt.Errorf("error storing refresh token: %w", err)
+	}
+
+	return uc.tokenResponse(user, raw)
+}
+
+// newRefreshToken returns a random token to hand out and its stored form.
+func (uc *UserUseCase) newRefreshToken() (*entity.RefreshToken, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("error generating refresh token: %w", err)
+	}
+	raw := base64.RawURLEncoding.EncodeToString(secret)
+
+	return &entity.RefreshToken{
+		Hash:      hashRefreshToken(raw),
+		ExpiresAt: time.Now().Add(uc.cfg.RefreshTokenTTL),
+	}, raw, nil
+}
+
+func (uc *UserUseCase) tokenResponse(user *entity.User, refreshToken string) (*dto.TokenResponse, error) {
+	now := time.Now()
+	claims := accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.Username,
+			Issuer:    uc.cfg.JWT.Issuer,
+			ExpiresAt: now.Add(uc.cfg.AccessTokenTTL).Unix(),
+			IssuedAt:  now.Unix(),
+		},
+		Role:   string(user.Role),
+		UserID: user.ID,
+	}
+	if uc.cfg.JWT.Audience != "" {
+		claims.Audience = jwt.Audience{uc.cfg.JWT.Audience}
+	}
+
+	accessToken, err := jwt.SignHS256(claims, []byte(uc.cfg.JWT.HS256Secret))
+	if err != nil {
+		return nil, fmt.Errorf("error signing access token: %w", err)
+	}
+
+	return &dto.TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(uc.cfg.AccessTokenTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int(uc.cfg.RefreshTokenTTL.Seconds()),
+		User:             dto.ToUserResponse(user),
+	}, nil
+}
+
+func hashRefreshToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/config"
+)
+
+func TestRegisterRejectsPasswordsBcryptCannotHash(t *testing.T) {
+	var cfg config.AuthConfig
+	cfg.JWT.HS256Secret = "test-secret"
+	// The password is rejected before the repository is used, so none is needed.
+	uc := NewUserUseCase(nil, cfg)
+
+	// 72 characters pass the request binding but take 144 bytes.
+	req := &dto.RegisterRequest{Username: "alice", Password: strings.Repeat("é", 72)}
+	if _, err := uc.Register(context.Background(), req); !errors.Is(err, ErrPasswordTooLong) {
+		t.Errorf("Register() error = %v, want %v", err, ErrPasswordTooLong)
+	}
+}
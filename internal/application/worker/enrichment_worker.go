@@ -0,0 +1,258 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/config"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/lyrics"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+// EnrichmentWorker fills release date, lyrics and link of newly created songs
+// from the music info API in the background. Rows are claimed with a lease so
+// that several application instances can share the queue.
+type EnrichmentWorker struct {
+	repo      repository.SongRepository
+	musicInfo repository.MusicInfoClient
+	config    config.EnrichmentConfig
+	logger    *logger.Logger
+
+	wakeup chan struct{}
+	jobs   chan *entity.Song
+	wg     sync.WaitGroup
+}
+
+func NewEnrichmentWorker(repo repository.SongRepository, musicInfo repository.MusicInfoClient, cfg config.EnrichmentConfig, logger *logger.Logger) *EnrichmentWorker {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = cfg.Workers
+	}
+
+	return &EnrichmentWorker{
+		repo:      repo,
+		musicInfo: musicInfo,
+		config:    cfg,
+		logger:    logger,
+		wakeup:    make(chan struct{}, 1),
+		jobs:      make(chan *entity.Song),
+	}
+}
+
+// Notify wakes the dispatcher up without waiting for the next poll tick.
+func (w *EnrichmentWorker) Notify() {
+	select {
+	case w.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs the dispatcher and the worker pool until ctx is cancelled.
+func (w *EnrichmentWorker) Start(ctx context.Context) {
+	w.logger.Info(ctx, "Starting enrichment worker pool",
+		zap.Int("workers", w.config.Workers),
+		zap.Duration("poll_interval", w.config.PollInterval))
+
+	for i := 0; i < w.config.Workers; i++ {
+		w.wg.Add(1)
+		go w.work(ctx)
+	}
+
+	w.wg.Add(1)
+	go w.dispatch(ctx)
+}
+
+// Wait blocks until every goroutine started by Start has returned.
+func (w *EnrichmentWorker) Wait() {
+	w.wg.Wait()
+}
+
+func (w *EnrichmentWorker) dispatch(ctx context.Context) {
+	defer w.wg.Done()
+	defer close(w.jobs)
+
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.claimAndQueue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.wakeup:
+		}
+	}
+}
+
+func (w *EnrichmentWorker) claimAndQueue(ctx context.Context) {
+	for {
+		songs, err := w.repo.ClaimForEnrichment(ctx, w.config.BatchSize, w.config.LeaseTimeout)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Error(ctx, "Failed to claim songs for enrichment", zap.Error(err))
+			}
+			return
+		}
+
+		for _, song := range songs {
+			select {
+			case w.jobs <- song:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if len(songs) < w.config.BatchSize {
+			return
+		}
+	}
+}
+
+func (w *EnrichmentWorker) work(ctx context.Context) {
+	defer w.wg.Done()
+
+	for song := range w.jobs {
+		w.enrich(ctx, song)
+	}
+}
+
+func (w *EnrichmentWorker) enrich(ctx context.Context, song *entity.Song) {
+	w.logger.Debug(ctx, "Enriching song",
+		zap.Int64("id", song.ID),
+		zap.Int("attempt", song.EnrichmentAttempts+1))
+
+	enrichment, err := w.fetch(ctx, song)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		w.fail(ctx, song, err)
+		return
+	}
+
+	if err := w.repo.CompleteEnrichment(ctx, song.ID, song.Version, enrichment); err != nil {
+		if errors.Is(err, repository.ErrEnrichmentNotClaimed) {
+			w.logger.Warn(ctx, "Song changed before enrichment finished, discarding the result", zap.Int64("id", song.ID))
+			return
+		}
+		w.logger.Error(ctx, "Failed to store song enrichment", zap.Int64("id", song.ID), zap.Error(err))
+		return
+	}
+
+	w.logger.Info(ctx, "Song successfully enriched", zap.Int64("id", song.ID))
+}
+
+func (w *EnrichmentWorker) fetch(ctx context.Context, song *entity.Song) (*entity.SongEnrichment, error) {
+	info, err := w.musicInfo.GetInfo(ctx, song.GroupName, song.SongName)
+	if err != nil {
+		return nil, err
+	}
+
+	text := lyrics.Normalize(info.Text)
+	enrichment := &entity.SongEnrichment{
+		Text:     text,
+		Sections: lyrics.Parse(text),
+		Link:     info.Link,
+	}
+
+	if info.ReleaseDate != "" {
+		releaseDate, err := time.Parse("02-01-2006", info.ReleaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing release date %q: %w", info.ReleaseDate, err)
+		}
+		enrichment.ReleaseDate = releaseDate
+	}
+
+	// Values the song already has, e.g. from a bulk import, win over the API.
+	if song.Text != "" {
+		enrichment.Text = song.Text
+		enrichment.Sections = lyrics.Parse(song.Text)
+	}
+	if song.Link != "" {
+		enrichment.Link = song.Link
+	}
+	if !song.ReleaseDate.IsZero() {
+		enrichment.ReleaseDate = song.ReleaseDate
+	}
+
+	if info.Album != nil && strings.TrimSpace(info.Album.Title) != "" {
+		album, err := toAlbumEnrichment(info.Album)
+		if err != nil {
+			return nil, err
+		}
+		enrichment.Album = album
+	}
+
+	return enrichment, nil
+}
+
+func toAlbumEnrichment(info *entity.MusicInfoAlbum) (*entity.AlbumEnrichment, error) {
+	album := &entity.AlbumEnrichment{
+		Title:       strings.TrimSpace(info.Title),
+		Type:        entity.AlbumTypeLP,
+		CoverLink:   info.Cover,
+		DiscNumber:  info.DiscNumber,
+		TrackNumber: info.TrackNumber,
+	}
+
+	switch strings.ToLower(info.Type) {
+	case "ep":
+		album.Type = entity.AlbumTypeEP
+	case "single":
+		album.Type = entity.AlbumTypeSingle
+	}
+
+	if info.ReleaseDate != "" {
+		releaseDate, err := time.Parse("02-01-2006", info.ReleaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing album release date %q: %w", info.ReleaseDate, err)
+		}
+		album.ReleaseDate = releaseDate
+	}
+
+	if album.DiscNumber <= 0 {
+		album.DiscNumber = 1
+	}
+
+	return album, nil
+}
+
+func (w *EnrichmentWorker) fail(ctx context.Context, song *entity.Song, cause error) {
+	attempts := song.EnrichmentAttempts + 1
+
+	var nextAttemptAt *time.Time
+	if attempts < w.config.MaxAttempts && !errors.Is(cause, repository.ErrMusicInfoNotFound) {
+		next := time.Now().Add(w.backoff(attempts))
+		nextAttemptAt = &next
+	}
+
+	w.logger.Warn(ctx, "Song enrichment failed",
+		zap.Int64("id", song.ID),
+		zap.Int("attempt", attempts),
+		zap.Bool("will_retry", nextAttemptAt != nil),
+		zap.Error(cause))
+
+	if err := w.repo.FailEnrichment(ctx, song.ID, song.Version, cause.Error(), nextAttemptAt); err != nil && !errors.Is(err, repository.ErrEnrichmentNotClaimed) {
+		w.logger.Error(ctx, "Failed to record enrichment failure", zap.Int64("id", song.ID), zap.Error(err))
+	}
+}
+
+func (w *EnrichmentWorker) backoff(attempt int) time.Duration {
+	delay := w.config.RetryBaseDelay << (attempt - 1)
+	if delay <= 0 || delay > w.config.RetryMaxDelay {
+		delay = w.config.RetryMaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,352 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"song-library/internal/config"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+// fakeSongRepo keeps songs in memory and follows the claim contract of the
+// postgres repository: a claim leases a waiting song until now+lease, and
+// results only apply while the song is still the claimed version, waiting
+// for enrichment and outside the trash. Other methods are not used by the
+// worker and panic through the nil embedded interface.
+type fakeSongRepo struct {
+	repository.SongRepository
+
+	mu        sync.Mutex
+	now       time.Time
+	songs     map[int64]*fakeSong
+	completed map[int64]*entity.SongEnrichment
+	leases    []time.Duration
+}
+
+type fakeSong struct {
+	song          entity.Song
+	nextAttemptAt *time.Time
+	deleted       bool
+}
+
+func newFakeSongRepo(songs ...entity.Song) *fakeSongRepo {
+	repo := &fakeSongRepo{
+		now:       time.Unix(1700000000, 0),
+		songs:     make(map[int64]*fakeSong),
+		completed: make(map[int64]*entity.SongEnrichment),
+	}
+	for _, song := range songs {
+		if song.EnrichmentStatus == "" {
+			song.EnrichmentStatus = entity.EnrichmentPending
+		}
+		if song.Version == 0 {
+			song.Version = 1
+		}
+		due := repo.now
+		repo.songs[song.ID] = &fakeSong{song: song, nextAttemptAt: &due}
+	}
+	return repo
+}
+
+func (r *fakeSongRepo) waiting(s *fakeSong) bool {
+	return !s.deleted && (s.song.EnrichmentStatus == entity.EnrichmentPending || s.song.EnrichmentStatus == entity.EnrichmentFailed)
+}
+
+func (r *fakeSongRepo) ClaimForEnrichment(ctx context.Context, limit int, lease time.Duration) ([]*entity.Song, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.leases = append(r.leases, lease)
+	var claimed []*entity.Song
+	for id := int64(1); id <= int64(len(r.songs)) && len(claimed) < limit; id++ {
+		s, ok := r.songs[id]
+		if !ok || !r.waiting(s) || s.nextAttemptAt == nil || s.nextAttemptAt.After(r.now) {
+			continue
+		}
+		until := r.now.Add(lease)
+		s.nextAttemptAt = &until
+		song := s.song
+		claimed = append(claimed, &song)
+	}
+	return claimed, nil
+}
+
+func (r *fakeSongRepo) CompleteEnrichment(ctx context.Context, id int64, version int, enrichment *entity.SongEnrichment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.songs[id]
+	if !ok || !r.waiting(s) || s.song.Version != version {
+		return repository.ErrEnrichmentNotClaimed
+	}
+	s.song.EnrichmentStatus = entity.EnrichmentDone
+	s.song.Version++
+	s.nextAttemptAt = nil
+	r.completed[id] = enrichment
+	return nil
+}
+
+func (r *fakeSongRepo) FailEnrichment(ctx context.Context, id int64, version int, reason string, nextAttemptAt *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.songs[id]
+	if !ok || !r.waiting(s) || s.song.Version != version {
+		return repository.ErrEnrichmentNotClaimed
+	}
+	s.song.EnrichmentStatus = entity.EnrichmentFailed
+	s.song.EnrichmentAttempts++
+	s.song.EnrichmentError = reason
+	s.song.Version++
+	s.nextAttemptAt = nextAttemptAt
+	return nil
+}
+
+func (r *fakeSongRepo) get(id int64) fakeSong {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.songs[id]
+}
+
+// fakeMusicInfo answers from a map keyed by song name; a missing song
+// returns err, or ErrMusicInfoNotFound when err is nil.
+type fakeMusicInfo struct {
+	infos map[string]*entity.MusicInfo
+	err   error
+	// beforeReturn runs before the answer is given, e.g. to edit the song
+	// while the request is in flight.
+	beforeReturn func()
+}
+
+func (c *fakeMusicInfo) GetInfo(ctx context.Context, group, song string) (*entity.MusicInfo, error) {
+	if c.beforeReturn != nil {
+		c.beforeReturn()
+	}
+	if info, ok := c.infos[song]; ok {
+		return info, nil
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return nil, repository.ErrMusicInfoNotFound
+}
+
+func newTestWorker(repo repository.SongRepository, client repository.MusicInfoClient) *EnrichmentWorker {
+	return NewEnrichmentWorker(repo, client, config.EnrichmentConfig{
+		Workers:        1,
+		BatchSize:      2,
+		PollInterval:   time.Hour,
+		LeaseTimeout:   5 * time.Minute,
+		MaxAttempts:    3,
+		RetryBaseDelay: time.Minute,
+		RetryMaxDelay:  10 * time.Minute,
+	}, logger.New("error"))
+}
+
+var uprising = &entity.MusicInfo{
+	ReleaseDate: "07-09-2009",
+	Text:        "Paranoia is in bloom",
+	Link:        "https://example.com/uprising",
+	Album:       &entity.MusicInfoAlbum{Title: " The Resistance ", Type: "ep", TrackNumber: 1},
+}
+
+func TestEnrichCompletesClaimedSong(t *testing.T) {
+	repo := newFakeSongRepo(entity.Song{ID: 1, GroupName: "Muse", SongName: "Uprising"})
+	w := newTestWorker(repo, &fakeMusicInfo{infos: map[string]*entity.MusicInfo{"Uprising": uprising}})
+
+	songs, _ := repo.ClaimForEnrichment(context.Background(), 1, time.Minute)
+	w.enrich(context.Background(), songs[0])
+
+	enrichment := repo.completed[1]
+	if enrichment == nil {
+		t.Fatalf("song was not enriched: %+v", repo.get(1).song)
+	}
+	if want := time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC); !enrichment.ReleaseDate.Equal(want) {
+		t.Errorf("release date = %v, want %v", enrichment.ReleaseDate, want)
+	}
+	if enrichment.Text != "Paranoia is in bloom" || enrichment.Link != "https://example.com/uprising" {
+		t.Errorf("enrichment = %+v", enrichment)
+	}
+	album := enrichment.Album
+	if album == nil || album.Title != "The Resistance" || album.Type != entity.AlbumTypeEP || album.DiscNumber != 1 {
+		t.Errorf("album = %+v", album)
+	}
+}
+
+func TestEnrichKeepsExistingValues(t *testing.T) {
+	released := time.Date(2010, 1, 2, 0, 0, 0, 0, time.UTC)
+	repo := newFakeSongRepo(entity.Song{
+		ID: 1, GroupName: "Muse", SongName: "Uprising",
+		Text: "Own lyrics", Link: "https://example.com/own", ReleaseDate: released,
+	})
+	w := newTestWorker(repo, &fakeMusicInfo{infos: map[string]*entity.MusicInfo{"Uprising": uprising}})
+
+	songs, _ := repo.ClaimForEnrichment(context.Background(), 1, time.Minute)
+	w.enrich(context.Background(), songs[0])
+
+	enrichment := repo.completed[1]
+	if enrichment == nil {
+		t.Fatal("song was not enriched")
+	}
+	if enrichment.Text != "Own lyrics" || enrichment.Link != "https://example.com/own" || !enrichment.ReleaseDate.Equal(released) {
+		t.Errorf("enrichment = %+v, want the song's own values", enrichment)
+	}
+}
+
+func TestEnrichDiscardsResultForChangedSong(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(s *fakeSong)
+	}{
+		{name: "edited", change: func(s *fakeSong) { s.song.Version++; s.song.EnrichmentStatus = entity.EnrichmentDone }},
+		{name: "edited without finishing enrichment", change: func(s *fakeSong) { s.song.Version++ }},
+		{name: "trashed", change: func(s *fakeSong) { s.deleted = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeSongRepo(entity.Song{ID: 1, GroupName: "Muse", SongName: "Uprising"})
+			client := &fakeMusicInfo{
+				infos:        map[string]*entity.MusicInfo{"Uprising": uprising},
+				beforeReturn: func() { tt.change(repo.songs[1]) },
+			}
+			w := newTestWorker(repo, client)
+
+			songs, _ := repo.ClaimForEnrichment(context.Background(), 1, time.Minute)
+			before := repo.get(1).song
+			w.enrich(context.Background(), songs[0])
+
+			if repo.completed[1] != nil {
+				t.Error("enrichment was stored for a song that changed after the claim")
+			}
+			if after := repo.get(1).song; after.Version != before.Version+1 && after.Version != before.Version {
+				t.Errorf("version = %d, want it left as the edit set it", after.Version)
+			}
+		})
+	}
+}
+
+func TestEnrichRecordsFailures(t *testing.T) {
+	unavailable := errors.New("music info API unavailable")
+
+	tests := []struct {
+		name        string
+		attempts    int
+		err         error
+		wantRetryIn time.Duration // 0 means no retry
+	}{
+		{name: "first failure retries after the base delay", attempts: 0, err: unavailable, wantRetryIn: time.Minute},
+		{name: "second failure doubles the delay", attempts: 1, err: unavailable, wantRetryIn: 2 * time.Minute},
+		{name: "last attempt gives up", attempts: 2, err: unavailable},
+		{name: "unknown song is not retried", attempts: 0, err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeSongRepo(entity.Song{ID: 1, GroupName: "Muse", SongName: "Unknown", EnrichmentAttempts: tt.attempts})
+			w := newTestWorker(repo, &fakeMusicInfo{err: tt.err})
+
+			songs, _ := repo.ClaimForEnrichment(context.Background(), 1, time.Minute)
+			start := time.Now()
+			w.enrich(context.Background(), songs[0])
+
+			s := repo.get(1)
+			if s.song.EnrichmentStatus != entity.EnrichmentFailed || s.song.EnrichmentAttempts != tt.attempts+1 {
+				t.Fatalf("song = %+v, want a recorded failure", s.song)
+			}
+			if tt.wantRetryIn == 0 {
+				if s.nextAttemptAt != nil {
+					t.Errorf("next attempt at %v, want none", s.nextAttemptAt)
+				}
+				return
+			}
+			if s.nextAttemptAt == nil {
+				t.Fatal("no next attempt scheduled")
+			}
+			if retryIn := s.nextAttemptAt.Sub(start); retryIn < tt.wantRetryIn || retryIn > tt.wantRetryIn+time.Second {
+				t.Errorf("retry in %v, want %v", retryIn, tt.wantRetryIn)
+			}
+		})
+	}
+}
+
+func TestEnrichFailureForChangedSongIsIgnored(t *testing.T) {
+	repo := newFakeSongRepo(entity.Song{ID: 1, GroupName: "Muse", SongName: "Unknown"})
+	client := &fakeMusicInfo{
+		err:          errors.New("music info API unavailable"),
+		beforeReturn: func() { repo.songs[1].song.Version++ },
+	}
+	w := newTestWorker(repo, client)
+
+	songs, _ := repo.ClaimForEnrichment(context.Background(), 1, time.Minute)
+	w.enrich(context.Background(), songs[0])
+
+	if s := repo.get(1).song; s.EnrichmentStatus != entity.EnrichmentPending || s.EnrichmentAttempts != 0 {
+		t.Errorf("song = %+v, want it untouched by the stale failure", s)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	w := newTestWorker(newFakeSongRepo(), &fakeMusicInfo{})
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Minute},
+		{attempt: 2, want: 2 * time.Minute},
+		{attempt: 3, want: 4 * time.Minute},
+		{attempt: 4, want: 8 * time.Minute},
+		{attempt: 5, want: 10 * time.Minute},
+		{attempt: 70, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := w.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestClaimAndQueueLeasesSongs(t *testing.T) {
+	repo := newFakeSongRepo(
+		entity.Song{ID: 1, SongName: "a"},
+		entity.Song{ID: 2, SongName: "b"},
+		entity.Song{ID: 3, SongName: "c"},
+	)
+	w := newTestWorker(repo, &fakeMusicInfo{})
+
+	// Nobody works the queue: the songs stay claimed but unfinished, as if
+	// the instance that claimed them had crashed.
+	w.jobs = make(chan *entity.Song, 10)
+	queued := func() int { return len(w.jobs) }
+
+	w.claimAndQueue(context.Background())
+	if queued() != 3 {
+		t.Fatalf("queued %d songs, want all three in full batches", queued())
+	}
+	for _, lease := range repo.leases {
+		if lease != 5*time.Minute {
+			t.Errorf("claimed with lease %v, want the configured %v", lease, 5*time.Minute)
+		}
+	}
+
+	w.claimAndQueue(context.Background())
+	if queued() != 3 {
+		t.Fatalf("queued %d songs, leased songs were claimed again", queued())
+	}
+
+	repo.mu.Lock()
+	repo.now = repo.now.Add(5 * time.Minute)
+	repo.mu.Unlock()
+
+	w.claimAndQueue(context.Background())
+	if queued() != 6 {
+		t.Errorf("queued %d songs, want the songs claimed again once their lease expired", queued())
+	}
+}
@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/config"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+// TrashPurger permanently deletes songs that have been in the trash for
+// longer than the retention period. Deletes run in batches so that a large
+// trash does not hold long locks.
+type TrashPurger struct {
+	repo   repository.SongRepository
+	config config.TrashConfig
+	logger *logger.Logger
+
+	wg sync.WaitGroup
+}
+
+func NewTrashPurger(repo repository.SongRepository, cfg config.TrashConfig, logger *logger.Logger) *TrashPurger {
+	if cfg.PurgeBatchSize <= 0 {
+		cfg.PurgeBatchSize = 500
+	}
+
+	return &TrashPurger{
+		repo:   repo,
+		config: cfg,
+		logger: logger,
+	}
+}
+
+// Start purges every PurgeInterval until ctx is cancelled. A zero interval
+// or retention disables the job; songs then stay in the trash until they are
+// purged with the purge-trash command.
+func (p *TrashPurger) Start(ctx context.Context) {
+	if p.config.PurgeInterval <= 0 || p.config.Retention <= 0 {
+		p.logger.Info(ctx, "Trash purge job disabled")
+		return
+	}
+
+	p.logger.Info(ctx, "Starting trash purge job",
+		zap.Duration("retention", p.config.Retention),
+		zap.Duration("interval", p.config.PurgeInterval))
+
+	p.wg.Add(1)
+	go p.run(ctx)
+}
+
+// Wait blocks until the goroutine started by Start has returned.
+func (p *TrashPurger) Wait() {
+	p.wg.Wait()
+}
+
+func (p *TrashPurger) run(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Purge(ctx, p.config.Retention); err != nil && ctx.Err() == nil {
+			p.logger.Error(ctx, "Failed to purge trash", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge deletes the songs that were moved to the trash more than retention
+// ago and returns how many there were.
+func (p *TrashPurger) Purge(ctx context.Context, retention time.Duration) (int, error) {
+	before := time.Now().Add(-retention)
+
+	purged := 0
+	for {
+		n, err := p.repo.PurgeDeleted(ctx, before, p.config.PurgeBatchSize)
+		purged += n
+		if err != nil {
+			return purged, err
+		}
+		if n < p.config.PurgeBatchSize {
+			break
+		}
+	}
+
+	if purged > 0 {
+		p.logger.Info(ctx, "Trash purged", zap.Int("songs", purged))
+	}
+	return purged, nil
+}
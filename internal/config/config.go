@@ -2,17 +2,24 @@ package config
 
 import (
 	"context"
+	"fmt"
 	"github.com/joho/godotenv"
 	"os"
 	"song-library/pkg/logger"
+	"strconv"
+	"strings"
+	"time"
 	"go.uber.org/zap"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	API      APIConfig
-	Log      struct {
+	Server     ServerConfig
+	Database   DatabaseConfig
+	API        APIConfig
+	Enrichment EnrichmentConfig
+	Trash      TrashConfig
+	Auth       AuthConfig
+	Log        struct {
 		Level string `env:"LOG_LEVEL" envDefault:"info"`
 	}
 }
@@ -31,7 +38,60 @@ type DatabaseConfig struct {
 }
 
 type APIConfig struct {
-	MusicInfoURL string
+	MusicInfoURL     string
+	Timeout          time.Duration
+	MaxRetries       int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+type EnrichmentConfig struct {
+	Workers        int
+	BatchSize      int
+	PollInterval   time.Duration
+	LeaseTimeout   time.Duration
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// TrashConfig controls how long deleted songs can be restored. Songs older
+// than Retention are purged every PurgeInterval.
+type TrashConfig struct {
+	Retention      time.Duration
+	PurgeInterval  time.Duration
+	PurgeBatchSize int
+}
+
+// AuthConfig lists the static API keys and the keys JWTs are verified with.
+// Requests without credentials can still read; see the routes for the role
+// each write needs. Users who log in get access tokens signed with the HS256
+// secret and refresh tokens stored in the database.
+type AuthConfig struct {
+	APIKeys         []APIKeyConfig
+	JWT             JWTConfig
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// APIKeyConfig is one entry of AUTH_API_KEYS, written as name:role:key. The
+// name identifies the caller in logs and revisions.
+type APIKeyConfig struct {
+	Name string
+	Role string
+	Key  string
+}
+
+// JWTConfig enables JWT authentication when a secret or a public key file is
+// set. Issuer and Audience are only checked when set.
+type JWTConfig struct {
+	HS256Secret        string
+	RS256PublicKeyFile string
+	Issuer             string
+	Audience           string
+	Leeway             time.Duration
 }
 
 func LoadConfig() (*Config, error) {
@@ -57,9 +117,46 @@ func LoadConfig() (*Config, error) {
 			DBName:   getEnv("DB_NAME", "song_library_db"),
 		},
 		API: APIConfig{
-			MusicInfoURL: getEnv("MUSIC_INFO_API_URL", "http://localhost:8081"),
+			MusicInfoURL:     getEnv("MUSIC_INFO_API_URL", "http://localhost:8081"),
+			Timeout:          getEnvDuration("MUSIC_INFO_API_TIMEOUT", 5*time.Second),
+			MaxRetries:       getEnvInt("MUSIC_INFO_API_MAX_RETRIES", 3),
+			RetryBaseDelay:   getEnvDuration("MUSIC_INFO_API_RETRY_BASE_DELAY", 200*time.Millisecond),
+			RetryMaxDelay:    getEnvDuration("MUSIC_INFO_API_RETRY_MAX_DELAY", 2*time.Second),
+			BreakerThreshold: getEnvInt("MUSIC_INFO_API_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:  getEnvDuration("MUSIC_INFO_API_BREAKER_COOLDOWN", 30*time.Second),
+		},
+		Enrichment: EnrichmentConfig{
+			Workers:        getEnvInt("ENRICHMENT_WORKERS", 4),
+			BatchSize:      getEnvInt("ENRICHMENT_BATCH_SIZE", 20),
+			PollInterval:   getEnvDuration("ENRICHMENT_POLL_INTERVAL", 5*time.Second),
+			LeaseTimeout:   getEnvDuration("ENRICHMENT_LEASE_TIMEOUT", 2*time.Minute),
+			MaxAttempts:    getEnvInt("ENRICHMENT_MAX_ATTEMPTS", 8),
+			RetryBaseDelay: getEnvDuration("ENRICHMENT_RETRY_BASE_DELAY", 30*time.Second),
+			RetryMaxDelay:  getEnvDuration("ENRICHMENT_RETRY_MAX_DELAY", time.Hour),
 		},
+		Trash: TrashConfig{
+			Retention:      getEnvDuration("TRASH_RETENTION", 30*24*time.Hour),
+			PurgeInterval:  getEnvDuration("TRASH_PURGE_INTERVAL", time.Hour),
+			PurgeBatchSize: getEnvInt("TRASH_PURGE_BATCH_SIZE", 500),
+		},
+		Auth: AuthConfig{
+			JWT: JWTConfig{
+				HS256Secret:        getEnv("AUTH_JWT_HS256_SECRET", ""),
+				RS256PublicKeyFile: getEnv("AUTH_JWT_RS256_PUBLIC_KEY_FILE", ""),
+				Issuer:             getEnv("AUTH_JWT_ISSUER", ""),
+				Audience:           getEnv("AUTH_JWT_AUDIENCE", ""),
+				Leeway:             getEnvDuration("AUTH_JWT_LEEWAY", 30*time.Second),
+			},
+			AccessTokenTTL:  getEnvDuration("AUTH_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: getEnvDuration("AUTH_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		},
+	}
+
+	apiKeys, err := parseAPIKeys(getEnv("AUTH_API_KEYS", ""))
+	if err != nil {
+		return nil, err
 	}
+	cfg.Auth.APIKeys = apiKeys
 
 	log.Info(ctx, "Конфигурация успешно загружена", 
 		zap.String("server_host", cfg.Server.Host),
@@ -70,9 +167,45 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// parseAPIKeys reads a comma separated list of name:role:key entries. The key
+// is everything after the second colon so that it may contain colons itself.
+func parseAPIKeys(value string) ([]APIKeyConfig, error) {
+	var keys []APIKeyConfig
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid AUTH_API_KEYS entry %q, expected name:role:key", strings.SplitN(entry, ":", 2)[0])
+		}
+		keys = append(keys, APIKeyConfig{Name: parts[0], Role: parts[1], Key: parts[2]})
+	}
+	return keys, nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+type AlbumType string
+
+const (
+	AlbumTypeLP     AlbumType = "LP"
+	AlbumTypeEP     AlbumType = "EP"
+	AlbumTypeSingle AlbumType = "single"
+)
+
+type Album struct {
+	ID          int64     `json:"id"`
+	ArtistID    int64     `json:"artist_id"`
+	ArtistName  string    `json:"artist_name"`
+	Title       string    `json:"title"`
+	Type        AlbumType `json:"type"`
+	ReleaseDate time.Time `json:"release_date"`
+	CoverLink   string    `json:"cover_link"`
+	TrackCount  int       `json:"track_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type AlbumFilter struct {
+	ArtistID int64     `json:"artist_id"`
+	Title    string    `json:"title"`
+	Type     AlbumType `json:"type"`
+	Page     int
+	PageSize int
+}
+
+type AlbumTrack struct {
+	AlbumID     int64 `json:"album_id"`
+	SongID      int64 `json:"song_id"`
+	DiscNumber  int   `json:"disc_number"`
+	TrackNumber int   `json:"track_number"`
+	Song        *Song `json:"song,omitempty"`
+}
+
+// AlbumEnrichment is the album data the music info API may return along with
+// a song; it is attached to the song's artist.
+type AlbumEnrichment struct {
+	Title       string
+	Type        AlbumType
+	ReleaseDate time.Time
+	CoverLink   string
+	DiscNumber  int
+	TrackNumber int
+}
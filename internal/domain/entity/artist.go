@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type Artist struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Country     string    `json:"country"`
+	FormedYear  int       `json:"formed_year"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ArtistFilter struct {
+	Name     string `json:"name"`
+	Country  string `json:"country"`
+	Page     int
+	PageSize int
+}
@@ -0,0 +1,29 @@
+package entity
+
+// DuplicateMode selects how likely duplicates are found. Normalized groups
+// songs whose group and song names are equal once case, accents, spacing and
+// punctuation are ignored; fuzzy pairs songs whose names are similar by
+// trigrams.
+type DuplicateMode string
+
+const (
+	DuplicatesNormalized DuplicateMode = "normalized"
+	DuplicatesFuzzy      DuplicateMode = "fuzzy"
+)
+
+type DuplicateQuery struct {
+	Mode DuplicateMode
+	// MinSimilarity applies to fuzzy mode; both the group and the song names
+	// of a pair must be at least this similar.
+	MinSimilarity float64
+	Page          int
+	PageSize      int
+}
+
+// DuplicateGroup is a set of songs that are probably the same song, oldest
+// first. Similarity is 1 for normalized groups and the mean similarity of the
+// group and song names for fuzzy pairs.
+type DuplicateGroup struct {
+	Songs      []*Song
+	Similarity float64
+}
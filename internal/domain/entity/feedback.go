@@ -0,0 +1,20 @@
+package entity
+
+// SongStats are the aggregates of the favorites, ratings and plays of a
+// song. AverageRating is 0 while the song has no ratings.
+type SongStats struct {
+	SongID        int64
+	FavoriteCount int
+	RatingCount   int
+	AverageRating float64
+	PlayCount     int64
+}
+
+// SongFeedback is what one user has said about a song. Rating is 0 when
+// the user has not rated it.
+type SongFeedback struct {
+	SongID   int64
+	Favorite bool
+	Rating   int
+	Stats    SongStats
+}
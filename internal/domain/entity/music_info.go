@@ -0,0 +1,20 @@
+package entity
+
+// MusicInfo is what the music info API knows about a song. Dates use the
+// DD-MM-YYYY format of the API.
+type MusicInfo struct {
+	ReleaseDate string          `json:"releaseDate"`
+	Text        string          `json:"text"`
+	Link        string          `json:"link"`
+	Album       *MusicInfoAlbum `json:"album,omitempty"`
+}
+
+// MusicInfoAlbum is optional; older upstream versions do not send it.
+type MusicInfoAlbum struct {
+	Title       string `json:"title"`
+	Type        string `json:"type"`
+	ReleaseDate string `json:"releaseDate"`
+	Cover       string `json:"cover"`
+	DiscNumber  int    `json:"discNumber"`
+	TrackNumber int    `json:"trackNumber"`
+}
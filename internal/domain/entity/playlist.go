@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+type PlaylistVisibility string
+
+const (
+	PlaylistPublic  PlaylistVisibility = "public"
+	PlaylistPrivate PlaylistVisibility = "private"
+)
+
+// Playlist belongs to a user. Public playlists can be read by anyone,
+// private ones only by their owner and admins.
+type Playlist struct {
+	ID            int64
+	UserID        int64
+	OwnerUsername string
+	Name          string
+	Description   string
+	Visibility    PlaylistVisibility
+	ItemCount     int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type PlaylistFilter struct {
+	Name   string
+	UserID int64
+	// ViewerID also lists the private playlists of that user; zero lists
+	// public playlists only. AllVisible lists every playlist, for admins.
+	ViewerID   int64
+	AllVisible bool
+	Page       int
+	PageSize   int
+}
+
+// PlaylistItem is one entry of a playlist. Position is its 1-based place
+// among the items whose songs are not in the trash.
+type PlaylistItem struct {
+	ID         int64
+	PlaylistID int64
+	SongID     int64
+	Position   int
+	AddedAt    time.Time
+	Song       *Song
+}
+
+// PlaylistAnchor places an item directly before or after another item of the
+// same playlist. Placing relative to an item rather than at an index keeps
+// the intent of an edit when others change the playlist at the same time.
+// The zero anchor means the end of the playlist.
+type PlaylistAnchor struct {
+	BeforeItemID int64
+	AfterItemID  int64
+}
+
+// PlaylistEntry is a track read from a playlist file. It is matched against
+// the library by group and title and, failing that, by link.
+type PlaylistEntry struct {
+	GroupName string
+	SongName  string
+	Link      string
+}
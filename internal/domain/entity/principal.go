@@ -0,0 +1,49 @@
+package entity
+
+import "fmt"
+
+// Role is what an authenticated caller may do. Every role includes the
+// permissions of the roles below it: readers only read, editors also create
+// and change songs, admins also delete, merge and manage the trash.
+type Role string
+
+const (
+	RoleReader Role = "reader"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleReader: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := roleRanks[role]; !ok {
+		return "", fmt.Errorf("unknown role %q", value)
+	}
+	return role, nil
+}
+
+// Allows reports whether the role includes the required role.
+func (r Role) Allows(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// Authentication methods a principal can be identified by.
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodJWT    = "jwt"
+)
+
+// Principal is the authenticated caller of a request. Subject is the API key
+// name or the JWT subject and is recorded as the editor of changes. UserID is
+// set for tokens issued to user accounts and zero otherwise.
+type Principal struct {
+	Subject string
+	Role    Role
+	Method  string
+	UserID  int64
+}
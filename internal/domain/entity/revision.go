@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// SongRevision keeps the values a song had before an edit. Revision numbers
+// start at 1 for every song; Editor and CreatedAt describe the edit that
+// replaced these values.
+type SongRevision struct {
+	ID           int64     `json:"id"`
+	SongID       int64     `json:"song_id"`
+	Revision     int       `json:"revision"`
+	GroupName    string    `json:"group_name"`
+	SongName     string    `json:"song_name"`
+	ReleaseDate  time.Time `json:"release_date"`
+	Text         string    `json:"text"`
+	Link         string    `json:"link"`
+	Editor       string    `json:"editor"`
+	RestoredFrom int       `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RevisionInfo describes an edit. RestoredFrom is set when the edit brings
+// back the values of an older revision.
+type RevisionInfo struct {
+	Editor       string
+	RestoredFrom int
+}
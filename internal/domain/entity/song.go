@@ -2,33 +2,197 @@ package entity
 
 import "time"
 
+type EnrichmentStatus string
+
+const (
+	EnrichmentPending EnrichmentStatus = "pending"
+	EnrichmentDone    EnrichmentStatus = "done"
+	EnrichmentFailed  EnrichmentStatus = "failed"
+)
+
 type Song struct {
-	ID          int64     `json:"id"`
-	GroupName   string    `json:"group_name"`
-	SongName    string    `json:"song_name"`
-	ReleaseDate time.Time `json:"release_date"`
-	Text        string    `json:"text"`
-	Link        string    `json:"link"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                 int64            `json:"id"`
+	ArtistID           int64            `json:"artist_id"`
+	GroupName          string           `json:"group_name"`
+	SongName           string           `json:"song_name"`
+	ReleaseDate        time.Time        `json:"release_date"`
+	Text               string           `json:"text"`
+	Link               string           `json:"link"`
+	EnrichmentStatus   EnrichmentStatus `json:"enrichment_status"`
+	EnrichmentAttempts int              `json:"enrichment_attempts"`
+	EnrichmentError    string           `json:"enrichment_error"`
+	Sections           []SongSection    `json:"sections,omitempty"`
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+	Version            int              `json:"version"`
+	// DeletedAt is set while the song is in the trash.
+	DeletedAt time.Time `json:"deleted_at"`
+	// The aggregates of favorites, ratings and plays; AverageRating is 0
+	// while the song has no ratings.
+	FavoriteCount int     `json:"favorite_count"`
+	RatingCount   int     `json:"rating_count"`
+	AverageRating float64 `json:"average_rating"`
+	PlayCount     int64   `json:"play_count"`
+	// SortKey holds the values of the list sort keys as text; List sets it
+	// so that cursors can be built from the last song of a page.
+	SortKey []string `json:"-"`
+}
+
+type SongEnrichment struct {
+	ReleaseDate time.Time
+	Text        string
+	Sections    []SongSection
+	Link        string
+	Album       *AlbumEnrichment
 }
 
+// NameMatch selects how GroupName and SongName of a SongFilter are compared;
+// all modes ignore case. Fuzzy tolerates typos: it matches names whose trigram
+// similarity (0 to 1) reaches MinSimilarity.
+type NameMatch string
+
+const (
+	MatchContains NameMatch = "contains"
+	MatchPrefix   NameMatch = "prefix"
+	MatchExact    NameMatch = "exact"
+	MatchFuzzy    NameMatch = "fuzzy"
+)
+
 type SongFilter struct {
+	ArtistID    int64     `json:"artist_id"`
 	GroupName   string    `json:"group_name"`
 	SongName    string    `json:"song_name"`
+	NameMatch   NameMatch `json:"name_match"`
 	ReleaseDate time.Time `json:"release_date"`
 	Text        string    `json:"text"`
 	Link        string    `json:"link"`
-	Page        int
-	PageSize    int
-}
-
-type SongText struct {
-	ID          int64    `json:"id"`
-	GroupName   string   `json:"group_name"`
-	SongName    string   `json:"song_name"`
-	Verses      []string `json:"verses"`
-	TotalVerses int      `json:"total_verses"`
-	Page        int      `json:"page"`
-	PageSize    int      `json:"page_size"`
+	// ReleasedFrom is inclusive, ReleasedBefore exclusive; zero means
+	// unbounded. The created and updated bounds are both inclusive.
+	ReleasedFrom   time.Time `json:"released_from"`
+	ReleasedBefore time.Time `json:"released_before"`
+	CreatedFrom    time.Time `json:"created_from"`
+	CreatedTo      time.Time `json:"created_to"`
+	UpdatedFrom    time.Time `json:"updated_from"`
+	UpdatedTo      time.Time `json:"updated_to"`
+	HasLyrics      *bool     `json:"has_lyrics"`
+	HasLink        *bool     `json:"has_link"`
+	MinSimilarity  float64   `json:"min_similarity"`
+	// Lower bounds on the aggregates; zero means unbounded.
+	MinAverageRating float64 `json:"min_average_rating"`
+	MinRatingCount   int     `json:"min_rating_count"`
+	MinFavoriteCount int     `json:"min_favorite_count"`
+	MinPlayCount     int64   `json:"min_play_count"`
+	Page             int
+	PageSize         int
+	// Sort lists the sort keys in order of precedence; id always breaks
+	// ties. Relevance ranks against Text.
+	Sort []SongSort
+	// Cursor switches to keyset pagination; Page is ignored then.
+	Cursor *SongCursor
+	// Lookahead fetches one song more than PageSize so that the caller can
+	// tell whether another page follows.
+	Lookahead bool
+	SkipTotal bool
+}
+
+const (
+	SortGroupName     = "group_name"
+	SortSongName      = "song_name"
+	SortReleaseDate   = "release_date"
+	SortCreatedAt     = "created_at"
+	SortUpdatedAt     = "updated_at"
+	SortRelevance     = "relevance"
+	SortSimilarity    = "similarity"
+	SortAverageRating = "average_rating"
+	SortRatingCount   = "rating_count"
+	SortFavoriteCount = "favorite_count"
+	SortPlayCount     = "play_count"
+)
+
+// NameSuggestion holds the closest known spellings of the names a search
+// found nothing for; a field is empty when there is no better spelling.
+type NameSuggestion struct {
+	GroupName string `json:"group_name"`
+	SongName  string `json:"song_name"`
+}
+
+// NameCompletionQuery asks for up to Limit distinct group and/or song names
+// starting with Prefix, ignoring case and accents.
+type NameCompletionQuery struct {
+	Prefix string
+	Limit  int
+	Groups bool
+	Songs  bool
+}
+
+type NameCompletions struct {
+	GroupNames []string
+	SongNames  []string
+}
+
+type SongSort struct {
+	Field string
+	Desc  bool
+}
+
+// SongCursor marks the song a page ended with; the next page starts after it,
+// or before it when Backward is set. Keys are that song's sort key values and
+// Sort the sort order they belong to.
+type SongCursor struct {
+	ID       int64    `json:"id"`
+	Sort     string   `json:"sort,omitempty"`
+	Keys     []string `json:"keys,omitempty"`
+	Backward bool     `json:"backward,omitempty"`
+}
+
+type SectionType string
+
+const (
+	SectionVerse  SectionType = "verse"
+	SectionChorus SectionType = "chorus"
+	SectionBridge SectionType = "bridge"
+	SectionIntro  SectionType = "intro"
+	SectionOutro  SectionType = "outro"
+)
+
+// SongSection is one block of lyrics. A repeat section carries no lines of its
+// own and stands for the earlier section with the same type and ordinal.
+type SongSection struct {
+	Type    SectionType `json:"type"`
+	Ordinal int         `json:"ordinal"`
+	Lines   []string    `json:"lines"`
+	Repeat  bool        `json:"repeat"`
+}
+
+type SongLyrics struct {
+	ID        int64         `json:"id"`
+	GroupName string        `json:"group_name"`
+	SongName  string        `json:"song_name"`
+	Text      string        `json:"text"`
+	Sections  []SongSection `json:"sections"`
+}
+
+// SyncedLine is one line of time-synced (LRC) lyrics. Words is only set for
+// enhanced LRC with per-word timing.
+type SyncedLine struct {
+	Start time.Duration `json:"start"`
+	Text  string        `json:"text"`
+	Words []SyncedWord  `json:"words,omitempty"`
+}
+
+type SyncedWord struct {
+	Start time.Duration `json:"start"`
+	Text  string        `json:"text"`
+}
+
+type SongSearchQuery struct {
+	Query    string
+	Page     int
+	PageSize int
+}
+
+type SongSearchResult struct {
+	Song    Song    `json:"song"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
 }
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// SongTranslation holds the lyrics of a song in another language. Language is
+// a canonical BCP-47 tag such as "en" or "pt-BR".
+type SongTranslation struct {
+	SongID    int64     `json:"song_id"`
+	Language  string    `json:"language"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
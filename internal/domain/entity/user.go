@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+type User struct {
+	ID           int64
+	Username     string
+	PasswordHash string
+	Role         Role
+	// DisabledAt is zero for active users. Disabled users cannot log in or
+	// refresh their tokens.
+	DisabledAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (u *User) Disabled() bool {
+	return !u.DisabledAt.IsZero()
+}
+
+type UserFilter struct {
+	Username string
+	Role     Role
+	// Disabled selects disabled (true) or active (false) users; nil selects
+	// both.
+	Disabled *bool
+	Page     int
+	PageSize int
+}
+
+// RefreshToken is a stored refresh token. Only the SHA-256 hash of the token
+// is kept; Family ties together all tokens that were rotated from the same
+// login.
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	Family    string
+	Hash      []byte
+	ExpiresAt time.Time
+}
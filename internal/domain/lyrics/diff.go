@@ -0,0 +1,114 @@
+package lyrics
+
+import "strings"
+
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// LineDiff is one line of a diff. OldLine and NewLine are 1-based line
+// numbers in the old and new text; the side a line is missing from is 0.
+type LineDiff struct {
+	Op      DiffOp
+	Text    string
+	OldLine int
+	NewLine int
+}
+
+// maxDiffCells bounds the LCS table. Texts whose differing middle part is
+// larger than this are reported as replaced as a whole.
+const maxDiffCells = 4_000_000
+
+// DiffLines compares two texts line by line using the longest common
+// subsequence, so moved or edited lines show up as a delete plus an insert.
+func DiffLines(oldText, newText string) []LineDiff {
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	var diff []LineDiff
+	for i := 0; i < prefix; i++ {
+		diff = append(diff, LineDiff{Op: DiffEqual, Text: oldLines[i], OldLine: i + 1, NewLine: i + 1})
+	}
+
+	a := oldLines[prefix : len(oldLines)-suffix]
+	b := newLines[prefix : len(newLines)-suffix]
+	diff = append(diff, diffMiddle(a, b, prefix)...)
+
+	for i := 0; i < suffix; i++ {
+		oldIdx := len(oldLines) - suffix + i
+		newIdx := len(newLines) - suffix + i
+		diff = append(diff, LineDiff{Op: DiffEqual, Text: oldLines[oldIdx], OldLine: oldIdx + 1, NewLine: newIdx + 1})
+	}
+
+	return diff
+}
+
+func diffMiddle(a, b []string, offset int) []LineDiff {
+	var diff []LineDiff
+
+	if len(a)*len(b) > maxDiffCells {
+		for i, line := range a {
+			diff = append(diff, LineDiff{Op: DiffDelete, Text: line, OldLine: offset + i + 1})
+		}
+		for j, line := range b {
+			diff = append(diff, LineDiff{Op: DiffInsert, Text: line, NewLine: offset + j + 1})
+		}
+		return diff
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			diff = append(diff, LineDiff{Op: DiffEqual, Text: a[i], OldLine: offset + i + 1, NewLine: offset + j + 1})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, LineDiff{Op: DiffDelete, Text: a[i], OldLine: offset + i + 1})
+			i++
+		default:
+			diff = append(diff, LineDiff{Op: DiffInsert, Text: b[j], NewLine: offset + j + 1})
+			j++
+		}
+	}
+
+	return diff
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,248 @@
+package lyrics
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"song-library/internal/domain/entity"
+)
+
+var ErrInvalidLRC = errors.New("invalid LRC")
+
+var (
+	lrcTimestamp = regexp.MustCompile(`^\[(\d{1,3}):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	lrcTag       = regexp.MustCompile(`^\[([A-Za-z#]+):(.*)\]$`)
+	lrcWordTag   = regexp.MustCompile(`<[^>]*>`)
+	lrcWordTime  = regexp.MustCompile(`^<(\d{1,3}):(\d{1,2})(?:[.:](\d{1,3}))?>$`)
+)
+
+// ParseLRC reads lyrics in LRC format, including enhanced LRC with per-word
+// <mm:ss.xx> timestamps. A line may carry several timestamps, as in
+// [00:12.00][00:45.00]chorus, and is then repeated at each of them. Lines are
+// returned in playback order and no two may start at the same time; metadata
+// tags other than [offset:] are ignored.
+func ParseLRC(data string) ([]entity.SyncedLine, error) {
+	if !utf8.ValidString(data) {
+		return nil, fmt.Errorf("%w: file is not valid UTF-8", ErrInvalidLRC)
+	}
+	data = strings.TrimPrefix(data, "\ufeff")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+
+	var entries []lrcEntry
+	var offset time.Duration
+
+	for i, raw := range strings.Split(data, "\n") {
+		lineNum := i + 1
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		if !lrcTimestamp.MatchString(raw) {
+			tag := lrcTag.FindStringSubmatch(raw)
+			if tag == nil {
+				if strings.HasPrefix(raw, "[") {
+					return nil, lrcError(lineNum, "malformed timestamp or tag %q", raw)
+				}
+				return nil, lrcError(lineNum, "line has no timestamp")
+			}
+			if strings.EqualFold(tag[1], "offset") {
+				ms, err := strconv.Atoi(strings.TrimSpace(tag[2]))
+				if err != nil {
+					return nil, lrcError(lineNum, "offset %q is not a number of milliseconds", tag[2])
+				}
+				offset = time.Duration(ms) * time.Millisecond
+			}
+			continue
+		}
+
+		var starts []time.Duration
+		rest := raw
+		for {
+			m := lrcTimestamp.FindStringSubmatch(rest)
+			if m == nil {
+				break
+			}
+			start, err := parseLRCTime(m)
+			if err != nil {
+				return nil, lrcError(lineNum, "%v", err)
+			}
+			starts = append(starts, start)
+			rest = rest[len(m[0]):]
+		}
+		rest = strings.TrimSpace(rest)
+
+		for _, start := range starts {
+			line, err := parseSyncedText(rest, start)
+			if err != nil {
+				return nil, lrcError(lineNum, "%v", err)
+			}
+			if len(line.Words) > 0 && len(starts) > 1 {
+				return nil, lrcError(lineNum, "word timestamps are not supported on a line with several timestamps")
+			}
+			entries = append(entries, lrcEntry{line: line, source: lineNum})
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no timed lines found", ErrInvalidLRC)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].line.Start < entries[j].line.Start
+	})
+
+	lines := make([]entity.SyncedLine, len(entries))
+	for i, entry := range entries {
+		line := entry.line
+		if i > 0 {
+			prev := entries[i-1]
+			if line.Start == prev.line.Start {
+				return nil, lrcError(entry.source, "timestamp %s overlaps line %d", FormatLRCTime(line.Start), prev.source)
+			}
+			// A closing word timestamp may end exactly where the next line starts.
+			if words := prev.line.Words; len(words) > 0 && words[len(words)-1].Start > line.Start {
+				return nil, lrcError(prev.source, "word timing overlaps the next line at %s", FormatLRCTime(line.Start))
+			}
+		}
+
+		if offset != 0 {
+			line.Start -= offset
+			if line.Start < 0 {
+				return nil, lrcError(entry.source, "offset moves the line before the start of the song")
+			}
+			for j := range line.Words {
+				line.Words[j].Start -= offset
+			}
+		}
+		lines[i] = line
+	}
+
+	return lines, nil
+}
+
+// lrcEntry is a timed line together with the file line it was read from.
+type lrcEntry struct {
+	line   entity.SyncedLine
+	source int
+}
+
+// FormatLRC writes lines as LRC. Word timing is kept, so enhanced LRC
+// survives an import/export round trip with centisecond precision.
+func FormatLRC(artist, title string, lines []entity.SyncedLine) string {
+	var b strings.Builder
+
+	if artist != "" {
+		fmt.Fprintf(&b, "[ar:%s]\n", artist)
+	}
+	if title != "" {
+		fmt.Fprintf(&b, "[ti:%s]\n", title)
+	}
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, line := range lines {
+		b.WriteString("[" + FormatLRCTime(line.Start) + "]")
+		if len(line.Words) == 0 {
+			b.WriteString(line.Text)
+		} else {
+			for i, word := range line.Words {
+				if i > 0 {
+					b.WriteString(" ")
+				}
+				b.WriteString("<" + FormatLRCTime(word.Start) + ">" + word.Text)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// FormatLRCTime renders d as mm:ss.xx.
+func FormatLRCTime(d time.Duration) string {
+	cs := d.Milliseconds() / 10
+	return fmt.Sprintf("%02d:%02d.%02d", cs/6000, cs/100%60, cs%100)
+}
+
+// ActiveLine returns the index of the line being sung at the given playback
+// position, or -1 before the first line starts.
+func ActiveLine(lines []entity.SyncedLine, at time.Duration) int {
+	return sort.Search(len(lines), func(i int) bool {
+		return lines[i].Start > at
+	}) - 1
+}
+
+func parseSyncedText(text string, start time.Duration) (entity.SyncedLine, error) {
+	line := entity.SyncedLine{Start: start}
+
+	tags := lrcWordTag.FindAllStringIndex(text, -1)
+	if len(tags) == 0 {
+		line.Text = text
+		return line, nil
+	}
+	if tags[0][0] != 0 && strings.TrimSpace(text[:tags[0][0]]) != "" {
+		return line, fmt.Errorf("text %q has no word timestamp", strings.TrimSpace(text[:tags[0][0]]))
+	}
+
+	var plain []string
+	prev := start
+	for i, tag := range tags {
+		m := lrcWordTime.FindStringSubmatch(text[tag[0]:tag[1]])
+		if m == nil {
+			return line, fmt.Errorf("malformed word timestamp %q", text[tag[0]:tag[1]])
+		}
+		wordStart, err := parseLRCTime(m)
+		if err != nil {
+			return line, err
+		}
+		if wordStart < prev {
+			return line, fmt.Errorf("word timestamp %s is out of order", FormatLRCTime(wordStart))
+		}
+		prev = wordStart
+
+		end := len(text)
+		if i+1 < len(tags) {
+			end = tags[i+1][0]
+		}
+		// A timestamp with no word after it marks where the previous word ends.
+		word := strings.TrimSpace(text[tag[1]:end])
+		line.Words = append(line.Words, entity.SyncedWord{Start: wordStart, Text: word})
+		if word != "" {
+			plain = append(plain, word)
+		}
+	}
+
+	line.Text = strings.Join(plain, " ")
+	return line, nil
+}
+
+func parseLRCTime(m []string) (time.Duration, error) {
+	minutes, _ := strconv.Atoi(m[1])
+	seconds, _ := strconv.Atoi(m[2])
+	if seconds >= 60 {
+		return 0, fmt.Errorf("timestamp %q has seconds out of range", m[0])
+	}
+
+	var ms int
+	if m[3] != "" {
+		fraction := m[3] + strings.Repeat("0", 3-len(m[3]))
+		ms, _ = strconv.Atoi(fraction)
+	}
+
+	return time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(ms)*time.Millisecond, nil
+}
+
+func lrcError(line int, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidLRC, line, fmt.Sprintf(format, args...))
+}
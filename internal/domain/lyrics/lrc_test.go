@@ -0,0 +1,175 @@
+package lyrics
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"song-library/internal/domain/entity"
+)
+
+func ms(n int) time.Duration {
+	return time.Duration(n) * time.Millisecond
+}
+
+func TestParseLRC(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []entity.SyncedLine
+		wantErr bool
+	}{
+		{
+			name: "plain lines",
+			data: "[ar:Muse]\n[00:01.00]first\n[00:02.50]second\n",
+			want: []entity.SyncedLine{
+				{Start: ms(1000), Text: "first"},
+				{Start: ms(2500), Text: "second"},
+			},
+		},
+		{
+			name: "several timestamps repeat the line in playback order",
+			data: "[00:12.00][00:45.00]chorus\n[00:20.00]verse",
+			want: []entity.SyncedLine{
+				{Start: ms(12000), Text: "chorus"},
+				{Start: ms(20000), Text: "verse"},
+				{Start: ms(45000), Text: "chorus"},
+			},
+		},
+		{
+			name: "lines out of order are sorted",
+			data: "[00:05.00]b\n[00:01.00]a",
+			want: []entity.SyncedLine{
+				{Start: ms(1000), Text: "a"},
+				{Start: ms(5000), Text: "b"},
+			},
+		},
+		{
+			name: "closing word timestamp may touch the next line",
+			data: "[00:01.00]<00:01.00>Hello <00:01.50>world <00:02.00>\n[00:02.00]next",
+			want: []entity.SyncedLine{
+				{Start: ms(1000), Text: "Hello world", Words: []entity.SyncedWord{
+					{Start: ms(1000), Text: "Hello"},
+					{Start: ms(1500), Text: "world"},
+					{Start: ms(2000), Text: ""},
+				}},
+				{Start: ms(2000), Text: "next"},
+			},
+		},
+		{
+			name: "positive offset moves lines earlier",
+			data: "[offset:500]\n[00:01.00]<00:01.00>a <00:01.20>b\n[00:02.00]c",
+			want: []entity.SyncedLine{
+				{Start: ms(500), Text: "a b", Words: []entity.SyncedWord{
+					{Start: ms(500), Text: "a"},
+					{Start: ms(700), Text: "b"},
+				}},
+				{Start: ms(1500), Text: "c"},
+			},
+		},
+		{
+			name: "negative offset moves lines later",
+			data: "[offset:-250]\n[00:01.00]a",
+			want: []entity.SyncedLine{{Start: ms(1250), Text: "a"}},
+		},
+		{
+			name:    "offset before the start of the song",
+			data:    "[offset:2000]\n[00:01.00]a",
+			wantErr: true,
+		},
+		{
+			name:    "offset is not a number",
+			data:    "[offset:soon]\n[00:01.00]a",
+			wantErr: true,
+		},
+		{
+			name:    "shared timestamp",
+			data:    "[00:01.00]a\n[00:01.00]b",
+			wantErr: true,
+		},
+		{
+			name:    "shared timestamp from a repeated line",
+			data:    "[00:01.00][00:03.00]a\n[00:03.00]b",
+			wantErr: true,
+		},
+		{
+			name:    "word timing runs into the next line",
+			data:    "[00:01.00]<00:01.00>a <00:02.50>b\n[00:02.00]c",
+			wantErr: true,
+		},
+		{
+			name:    "word timing on a repeated line",
+			data:    "[00:01.00][00:05.00]<00:01.00>a",
+			wantErr: true,
+		},
+		{
+			name:    "seconds out of range",
+			data:    "[00:61.00]a",
+			wantErr: true,
+		},
+		{
+			name:    "line without timestamp",
+			data:    "[00:01.00]a\nb",
+			wantErr: true,
+		},
+		{
+			name:    "no timed lines",
+			data:    "[ti:Title]\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid UTF-8",
+			data:    "[00:01.00]\xff",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLRC(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidLRC) {
+					t.Fatalf("ParseLRC() error = %v, want ErrInvalidLRC", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLRC() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLRC() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActiveLine(t *testing.T) {
+	lines := []entity.SyncedLine{
+		{Start: ms(1000), Text: "a"},
+		{Start: ms(2000), Text: "b"},
+		{Start: ms(4000), Text: "c"},
+	}
+
+	tests := []struct {
+		name  string
+		lines []entity.SyncedLine
+		at    time.Duration
+		want  int
+	}{
+		{name: "before the first line", lines: lines, at: ms(999), want: -1},
+		{name: "first line starts", lines: lines, at: ms(1000), want: 0},
+		{name: "between lines", lines: lines, at: ms(3999), want: 1},
+		{name: "last line starts", lines: lines, at: ms(4000), want: 2},
+		{name: "after the last line", lines: lines, at: time.Hour, want: 2},
+		{name: "no lines", lines: nil, at: ms(1000), want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ActiveLine(tt.lines, tt.at); got != tt.want {
+				t.Errorf("ActiveLine(%v) = %d, want %d", tt.at, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package lyrics
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"song-library/internal/domain/entity"
+)
+
+var markerKeywords = map[string]entity.SectionType{
+	"verse":      entity.SectionVerse,
+	"куплет":     entity.SectionVerse,
+	"chorus":     entity.SectionChorus,
+	"refrain":    entity.SectionChorus,
+	"hook":       entity.SectionChorus,
+	"припев":     entity.SectionChorus,
+	"bridge":     entity.SectionBridge,
+	"pre-chorus": entity.SectionBridge,
+	"prechorus":  entity.SectionBridge,
+	"pre chorus": entity.SectionBridge,
+	"interlude":  entity.SectionBridge,
+	"бридж":      entity.SectionBridge,
+	"intro":      entity.SectionIntro,
+	"вступление": entity.SectionIntro,
+	"outro":      entity.SectionOutro,
+	"coda":       entity.SectionOutro,
+	"кода":       entity.SectionOutro,
+}
+
+var (
+	bracketMarker = regexp.MustCompile(`^[\[(](.+)[\])]$`)
+	colonMarker   = regexp.MustCompile(`^([^:]+):$`)
+	markerBody    = regexp.MustCompile(`^(.+?)(?:\s+(\d+))?(?:\s*[x×]\s*\d+)?$`)
+	blankLines    = regexp.MustCompile(`\n{3,}`)
+)
+
+// Normalize unifies line endings and whitespace so that the same lyrics
+// always produce the same text regardless of where they were pasted from.
+func Normalize(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	text = strings.Join(lines, "\n")
+	text = blankLines.ReplaceAllString(text, "\n\n")
+	return strings.Trim(text, "\n")
+}
+
+// Parse splits lyrics into sections. Explicit markers such as "[Chorus]",
+// "(Verse 2)" or "Bridge:" set the section type; a marker with no lines
+// below it repeats the last section of that type. Unmarked blocks separated
+// by blank lines become verses, or choruses when the same block occurs more
+// than once.
+func Parse(text string) []entity.SongSection {
+	blocks := splitBlocks(Normalize(text))
+	classifyUnmarked(blocks)
+
+	var sections []entity.SongSection
+	counters := make(map[entity.SectionType]int)
+	bodies := make(map[entity.SectionType]map[string]int)
+
+	for _, b := range blocks {
+		if len(b.lines) == 0 {
+			ordinal := b.ordinal
+			if ordinal == 0 || ordinal > counters[b.typ] {
+				ordinal = counters[b.typ]
+			}
+			if ordinal == 0 {
+				continue
+			}
+			sections = append(sections, entity.SongSection{Type: b.typ, Ordinal: ordinal, Repeat: true})
+			continue
+		}
+
+		body := strings.Join(b.lines, "\n")
+		if ordinal, seen := bodies[b.typ][body]; seen {
+			sections = append(sections, entity.SongSection{Type: b.typ, Ordinal: ordinal, Repeat: true})
+			continue
+		}
+
+		counters[b.typ]++
+		if bodies[b.typ] == nil {
+			bodies[b.typ] = make(map[string]int)
+		}
+		bodies[b.typ][body] = counters[b.typ]
+
+		sections = append(sections, entity.SongSection{
+			Type:    b.typ,
+			Ordinal: counters[b.typ],
+			Lines:   b.lines,
+		})
+	}
+
+	return sections
+}
+
+// Expand fills repeat sections with the lines of the section they repeat.
+func Expand(sections []entity.SongSection) []entity.SongSection {
+	originals := make(map[entity.SectionType]map[int][]string)
+	for _, s := range sections {
+		if s.Repeat {
+			continue
+		}
+		if originals[s.Type] == nil {
+			originals[s.Type] = make(map[int][]string)
+		}
+		originals[s.Type][s.Ordinal] = s.Lines
+	}
+
+	expanded := make([]entity.SongSection, len(sections))
+	for i, s := range sections {
+		if s.Repeat {
+			s.Lines = originals[s.Type][s.Ordinal]
+		}
+		expanded[i] = s
+	}
+	return expanded
+}
+
+// Render returns the section as a block of text. Repeat sections that were
+// not expanded are shown as their marker, e.g. "[Chorus]".
+func Render(section entity.SongSection) string {
+	if section.Repeat && len(section.Lines) == 0 {
+		return "[" + strings.ToUpper(string(section.Type[:1])) + string(section.Type[1:]) + "]"
+	}
+	return strings.Join(section.Lines, "\n")
+}
+
+type block struct {
+	typ      entity.SectionType
+	ordinal  int
+	explicit bool
+	lines    []string
+}
+
+func splitBlocks(text string) []*block {
+	var blocks []*block
+	var current *block
+
+	flush := func() {
+		if current != nil && (len(current.lines) > 0 || current.explicit) {
+			blocks = append(blocks, current)
+		}
+		current = nil
+	}
+
+	if text == "" {
+		return nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if typ, ordinal, ok := parseMarker(line); ok {
+			flush()
+			current = &block{typ: typ, ordinal: ordinal, explicit: true}
+			continue
+		}
+
+		if line == "" {
+			if current != nil && len(current.lines) > 0 {
+				flush()
+			}
+			continue
+		}
+
+		if current == nil {
+			current = &block{}
+		}
+		current.lines = append(current.lines, line)
+	}
+	flush()
+
+	return blocks
+}
+
+func classifyUnmarked(blocks []*block) {
+	occurrences := make(map[string]int)
+	for _, b := range blocks {
+		if !b.explicit {
+			occurrences[strings.Join(b.lines, "\n")]++
+		}
+	}
+
+	choruses := make(map[string]bool)
+	for _, b := range blocks {
+		if b.explicit && b.typ == entity.SectionChorus && len(b.lines) > 0 {
+			choruses[strings.Join(b.lines, "\n")] = true
+		}
+	}
+
+	for _, b := range blocks {
+		if b.explicit {
+			continue
+		}
+		body := strings.Join(b.lines, "\n")
+		if occurrences[body] > 1 || choruses[body] {
+			b.typ = entity.SectionChorus
+		} else {
+			b.typ = entity.SectionVerse
+		}
+	}
+}
+
+func parseMarker(line string) (entity.SectionType, int, bool) {
+	var inner string
+	if m := bracketMarker.FindStringSubmatch(line); m != nil {
+		inner = m[1]
+	} else if m := colonMarker.FindStringSubmatch(line); m != nil {
+		inner = m[1]
+	} else {
+		return "", 0, false
+	}
+
+	if i := strings.Index(inner, ":"); i >= 0 {
+		inner = inner[:i]
+	}
+	inner = strings.ToLower(strings.TrimSpace(inner))
+
+	m := markerBody.FindStringSubmatch(inner)
+	if m == nil {
+		return "", 0, false
+	}
+
+	typ, ok := markerKeywords[strings.TrimSpace(m[1])]
+	if !ok {
+		return "", 0, false
+	}
+
+	ordinal := 0
+	if m[2] != "" {
+		ordinal, _ = strconv.Atoi(m[2])
+	}
+	return typ, ordinal, true
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"song-library/internal/domain/entity"
+)
+
+type AlbumRepository interface {
+	Create(ctx context.Context, album *entity.Album) error
+	Update(ctx context.Context, album *entity.Album) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*entity.Album, error)
+	List(ctx context.Context, filter *entity.AlbumFilter) ([]*entity.Album, int, error)
+
+	SetTrack(ctx context.Context, track *entity.AlbumTrack) error
+	RemoveTrack(ctx context.Context, albumID, songID int64) error
+	ListTracks(ctx context.Context, albumID int64) ([]*entity.AlbumTrack, error)
+}
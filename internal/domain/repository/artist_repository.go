@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"song-library/internal/domain/entity"
+)
+
+type ArtistRepository interface {
+	Create(ctx context.Context, artist *entity.Artist) error
+	Update(ctx context.Context, artist *entity.Artist) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*entity.Artist, error)
+	List(ctx context.Context, filter *entity.ArtistFilter) ([]*entity.Artist, int, error)
+}
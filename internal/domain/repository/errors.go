@@ -3,5 +3,36 @@ package repository
 import "errors"
 
 var (
-	ErrSongNotFound = errors.New("song not found")
+	ErrSongNotFound        = errors.New("song not found")
+	ErrSongAlreadyExists   = errors.New("song already exists")
+	ErrSongVersionConflict = errors.New("song was modified by someone else")
+	ErrInvalidSearchQuery  = errors.New("invalid search query")
+	ErrInvalidSort         = errors.New("invalid sort")
+
+	ErrMusicInfoNotFound    = errors.New("song not found in music info API")
+	ErrEnrichmentNotClaimed = errors.New("song changed since it was claimed for enrichment")
+
+	ErrSyncedLyricsNotFound = errors.New("song has no synced lyrics")
+	ErrRevisionNotFound     = errors.New("revision not found")
+
+	ErrTranslationNotFound      = errors.New("translation not found")
+	ErrTranslationAlreadyExists = errors.New("translation already exists")
+
+	ErrArtistNotFound      = errors.New("artist not found")
+	ErrArtistAlreadyExists = errors.New("artist already exists")
+	ErrArtistInUse         = errors.New("artist is still referenced by songs or albums")
+
+	ErrAlbumNotFound      = errors.New("album not found")
+	ErrAlbumAlreadyExists = errors.New("album already exists")
+	ErrTrackNotFound      = errors.New("track not found on album")
+	ErrTrackPositionTaken = errors.New("track position already taken")
+
+	ErrUserNotFound        = errors.New("user not found")
+	ErrUserAlreadyExists   = errors.New("user already exists")
+	ErrUserDisabled        = errors.New("user is disabled")
+	ErrRefreshTokenInvalid = errors.New("refresh token is invalid or expired")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used")
+
+	ErrPlaylistNotFound     = errors.New("playlist not found")
+	ErrPlaylistItemNotFound = errors.New("playlist item not found")
 )
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"song-library/internal/domain/entity"
+)
+
+// FeedbackRepository records favorites, ratings and plays and keeps the
+// aggregates on the song in step with them. Songs in the trash take no new
+// feedback.
+type FeedbackRepository interface {
+	GetFeedback(ctx context.Context, userID, songID int64) (*entity.SongFeedback, error)
+	AddFavorite(ctx context.Context, userID, songID int64) (*entity.SongStats, error)
+	RemoveFavorite(ctx context.Context, userID, songID int64) (*entity.SongStats, error)
+	// ListFavorites returns the user's favorite songs, most recently added
+	// first, leaving out songs in the trash.
+	ListFavorites(ctx context.Context, userID int64, page, pageSize int) ([]*entity.Song, int, error)
+	Rate(ctx context.Context, userID, songID int64, rating int) (*entity.SongStats, error)
+	RemoveRating(ctx context.Context, userID, songID int64) (*entity.SongStats, error)
+	// RecordPlay counts a play; userID is zero for callers without a user
+	// account.
+	RecordPlay(ctx context.Context, songID, userID int64) (*entity.SongStats, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"song-library/internal/domain/entity"
+)
+
+// MusicInfoClient looks songs up in the music info API. It returns
+// ErrMusicInfoNotFound when the API does not know the song.
+type MusicInfoClient interface {
+	GetInfo(ctx context.Context, group, song string) (*entity.MusicInfo, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"song-library/internal/domain/entity"
+)
+
+type PlaylistRepository interface {
+	Create(ctx context.Context, playlist *entity.Playlist) error
+	Update(ctx context.Context, playlist *entity.Playlist) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*entity.Playlist, error)
+	List(ctx context.Context, filter *entity.PlaylistFilter) ([]*entity.Playlist, int, error)
+
+	// ListItems returns the items in playlist order, leaving out songs in the
+	// trash. Song texts are only loaded with withLyrics.
+	ListItems(ctx context.Context, playlistID int64, withLyrics bool, page, pageSize int) ([]*entity.PlaylistItem, int, error)
+	AddItem(ctx context.Context, item *entity.PlaylistItem, anchor entity.PlaylistAnchor) error
+	// AppendItems adds the songs to the end of the playlist in the given
+	// order and returns the ids of the new items.
+	AppendItems(ctx context.Context, playlistID int64, songIDs []int64) ([]int64, error)
+	MoveItem(ctx context.Context, playlistID, itemID int64, anchor entity.PlaylistAnchor) (*entity.PlaylistItem, error)
+	RemoveItem(ctx context.Context, playlistID, itemID int64) error
+}
@@ -3,13 +3,54 @@ package repository
 import (
 	"context"
 	"song-library/internal/domain/entity"
+	"time"
 )
 
 type SongRepository interface {
 	Create(ctx context.Context, song *entity.Song) error
-	Update(ctx context.Context, song *entity.Song) error
+	CreateBatch(ctx context.Context, songs []*entity.Song) ([]error, error)
+	Update(ctx context.Context, song *entity.Song, info entity.RevisionInfo) error
+	UpdateIfVersion(ctx context.Context, song *entity.Song, info entity.RevisionInfo, version int) error
 	Delete(ctx context.Context, id int64) error
+	DeleteIfVersion(ctx context.Context, id int64, version int) error
+	ListDeleted(ctx context.Context, page, pageSize int) ([]*entity.Song, int, error)
+	Restore(ctx context.Context, id int64) (*entity.Song, error)
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error)
 	GetByID(ctx context.Context, id int64) (*entity.Song, error)
 	List(ctx context.Context, filter *entity.SongFilter) ([]*entity.Song, int, error)
-	GetSongTextByVerses(ctx context.Context, id int64, page, pageSize int) (*entity.SongText, error)
+	Iterate(filter *entity.SongFilter, batchSize int) SongIterator
+	GetLyrics(ctx context.Context, id int64) (*entity.SongLyrics, error)
+	ListWithoutSections(ctx context.Context, afterID int64, limit int) ([]*entity.SongLyrics, error)
+	SaveSections(ctx context.Context, id int64, sections []entity.SongSection) error
+	GetSyncedLyrics(ctx context.Context, id int64) ([]entity.SyncedLine, error)
+	SaveSyncedLyrics(ctx context.Context, id int64, lines []entity.SyncedLine) error
+	DeleteSyncedLyrics(ctx context.Context, id int64) error
+	Search(ctx context.Context, query *entity.SongSearchQuery) ([]*entity.SongSearchResult, int, error)
+	SuggestNames(ctx context.Context, groupName, songName string) (*entity.NameSuggestion, error)
+	CompleteNames(ctx context.Context, query *entity.NameCompletionQuery) (*entity.NameCompletions, error)
+	FindDuplicates(ctx context.Context, query *entity.DuplicateQuery) ([]*entity.DuplicateGroup, int, error)
+	Merge(ctx context.Context, keeperID int64, duplicateIDs []int64, info entity.RevisionInfo) (*entity.Song, error)
+
+	// MatchEntries returns the song each entry refers to, in entry order, and
+	// nil for entries that match no song outside the trash.
+	MatchEntries(ctx context.Context, entries []entity.PlaylistEntry) ([]*entity.Song, error)
+
+	ListRevisions(ctx context.Context, songID int64, page, pageSize int) ([]*entity.SongRevision, int, error)
+	GetRevision(ctx context.Context, songID int64, revision int) (*entity.SongRevision, error)
+
+	ClaimForEnrichment(ctx context.Context, limit int, lease time.Duration) ([]*entity.Song, error)
+	// CompleteEnrichment and FailEnrichment only apply while the song is
+	// still the claimed version, waiting for enrichment and outside the
+	// trash; otherwise they change nothing and return ErrEnrichmentNotClaimed.
+	CompleteEnrichment(ctx context.Context, id int64, version int, enrichment *entity.SongEnrichment) error
+	FailEnrichment(ctx context.Context, id int64, version int, reason string, nextAttemptAt *time.Time) error
+}
+
+// SongIterator walks over the songs matching a filter in id order. Songs are
+// fetched in batches with separate queries, so a long walk holds no
+// transaction open and sees changes made while it runs.
+type SongIterator interface {
+	Next(ctx context.Context) bool
+	Song() *entity.Song
+	Err() error
 }
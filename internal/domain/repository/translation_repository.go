@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"song-library/internal/domain/entity"
+)
+
+type TranslationRepository interface {
+	Create(ctx context.Context, translation *entity.SongTranslation) error
+	Update(ctx context.Context, translation *entity.SongTranslation) error
+	Delete(ctx context.Context, songID int64, language string) error
+	Get(ctx context.Context, songID int64, language string) (*entity.SongTranslation, error)
+	List(ctx context.Context, songID int64) ([]*entity.SongTranslation, error)
+}
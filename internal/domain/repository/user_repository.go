@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"song-library/internal/domain/entity"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user *entity.User) error
+	GetByID(ctx context.Context, id int64) (*entity.User, error)
+	GetByUsername(ctx context.Context, username string) (*entity.User, error)
+	List(ctx context.Context, filter *entity.UserFilter) ([]*entity.User, int, error)
+	UpdateRole(ctx context.Context, id int64, role entity.Role) (*entity.User, error)
+	// SetDisabled disables or re-enables a user. Disabling also revokes all
+	// of the user's refresh tokens.
+	SetDisabled(ctx context.Context, id int64, disabled bool) (*entity.User, error)
+
+	CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error
+	// RotateRefreshToken revokes the token with the given hash and stores next
+	// in the same family, returning the token's user. A token that was
+	// already rotated revokes its whole family and fails with
+	// ErrRefreshTokenReused.
+	RotateRefreshToken(ctx context.Context, hash []byte, next *entity.RefreshToken) (*entity.User, error)
+	// RevokeRefreshToken revokes the family of the token with the given hash.
+	// Unknown tokens are ignored.
+	RevokeRefreshToken(ctx context.Context, hash []byte) error
+}
@@ -0,0 +1,146 @@
+// Package auth identifies API callers by static API key or by a JWT issued
+// elsewhere and verified locally.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+
+	"song-library/internal/config"
+	"song-library/internal/domain/entity"
+	"song-library/pkg/jwt"
+	"song-library/pkg/logger"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrJWTDisabled        = errors.New("jwt authentication is not configured")
+)
+
+// Authenticator turns credentials into a principal. API keys are looked up
+// by their SHA-256 hash, so the lookup time says nothing about how much of a
+// guessed key was right.
+type Authenticator struct {
+	apiKeys  map[[sha256.Size]byte]entity.Principal
+	verifier *jwt.Verifier
+}
+
+// tokenClaims are the claims read from a JWT. The role is taken from "role"
+// or, when the issuer sends several, the highest known role in "roles". Only
+// tokens issued at login carry "uid".
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Role   string   `json:"role"`
+	Roles  []string `json:"roles"`
+	UserID int64    `json:"uid"`
+}
+
+func NewAuthenticator(cfg config.AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{apiKeys: make(map[[sha256.Size]byte]entity.Principal, len(cfg.APIKeys))}
+
+	for _, apiKey := range cfg.APIKeys {
+		role, err := entity.ParseRole(apiKey.Role)
+		if err != nil {
+			return nil, fmt.Errorf("api key %q: %w", apiKey.Name, err)
+		}
+		hash := sha256.Sum256([]byte(apiKey.Key))
+		if _, ok := a.apiKeys[hash]; ok {
+			return nil, fmt.Errorf("api key %q: key is used more than once", apiKey.Name)
+		}
+		a.apiKeys[hash] = entity.Principal{Subject: apiKey.Name, Role: role, Method: entity.AuthMethodAPIKey}
+	}
+
+	var keys jwt.Keys
+	if cfg.JWT.HS256Secret != "" {
+		keys.HMACSecret = []byte(cfg.JWT.HS256Secret)
+	}
+	if cfg.JWT.RS256PublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.JWT.RS256PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading jwt public key: %w", err)
+		}
+		keys.RSAPublicKey, err = jwt.ParseRSAPublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jwt public key: %w", err)
+		}
+	}
+	if keys.HMACSecret != nil || keys.RSAPublicKey != nil {
+		verifier, err := jwt.NewVerifier(keys)
+		if err != nil {
+			return nil, err
+		}
+		verifier.Issuer = cfg.JWT.Issuer
+		verifier.Audience = cfg.JWT.Audience
+		verifier.Leeway = cfg.JWT.Leeway
+		a.verifier = verifier
+	}
+
+	return a, nil
+}
+
+// Enabled reports whether any credentials can be accepted at all. Without
+// them every protected route answers 401.
+func (a *Authenticator) Enabled() bool {
+	return len(a.apiKeys) > 0 || a.verifier != nil
+}
+
+func (a *Authenticator) AuthenticateAPIKey(key string) (*entity.Principal, error) {
+	principal, ok := a.apiKeys[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+	return &principal, nil
+}
+
+func (a *Authenticator) AuthenticateToken(token string) (*entity.Principal, error) {
+	if a.verifier == nil {
+		return nil, ErrJWTDisabled
+	}
+
+	var claims tokenClaims
+	if err := a.verifier.Verify(token, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+
+	return &entity.Principal{
+		Subject: claims.Subject,
+		Role:    tokenRole(claims),
+		Method:  entity.AuthMethodJWT,
+		UserID:  claims.UserID,
+	}, nil
+}
+
+// tokenRole ignores roles this API does not know, so that tokens shared with
+// other services keep working; a token without a known role can only read.
+func tokenRole(claims tokenClaims) entity.Role {
+	role := entity.RoleReader
+	for _, value := range append([]string{claims.Role}, claims.Roles...) {
+		if parsed, err := entity.ParseRole(value); err == nil && parsed.Allows(role) {
+			role = parsed
+		}
+	}
+	return role
+}
+
+type principalKey struct{}
+
+// WithPrincipal stores the principal in the context. Its subject is also
+// stored under logger.PrincipalTrackerKey so that every log line of the
+// request names the caller.
+func WithPrincipal(ctx context.Context, principal *entity.Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, principal)
+	return context.WithValue(ctx, logger.PrincipalTrackerKey, principal.Subject)
+}
+
+// PrincipalFromContext returns the authenticated caller, or nil for an
+// anonymous request.
+func PrincipalFromContext(ctx context.Context) *entity.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*entity.Principal)
+	return principal
+}
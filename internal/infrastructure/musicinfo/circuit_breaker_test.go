@@ -0,0 +1,120 @@
+package musicinfo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	type step struct {
+		action    string // allow, success, failure, release or wait
+		wait      time.Duration
+		wantAllow bool
+		wantState string
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "stays closed below the threshold",
+			steps: []step{
+				{action: "failure", wantState: "closed"},
+				{action: "failure", wantState: "closed"},
+				{action: "allow", wantAllow: true, wantState: "closed"},
+			},
+		},
+		{
+			name: "success resets the failure count",
+			steps: []step{
+				{action: "failure", wantState: "closed"},
+				{action: "failure", wantState: "closed"},
+				{action: "success", wantState: "closed"},
+				{action: "failure", wantState: "closed"},
+				{action: "failure", wantState: "closed"},
+				{action: "allow", wantAllow: true, wantState: "closed"},
+			},
+		},
+		{
+			name: "opens at the threshold and fails fast during the cooldown",
+			steps: []step{
+				{action: "failure", wantState: "closed"},
+				{action: "failure", wantState: "closed"},
+				{action: "failure", wantState: "open"},
+				{action: "allow", wantAllow: false, wantState: "open"},
+				{action: "wait", wait: 59 * time.Second, wantState: "open"},
+				{action: "allow", wantAllow: false, wantState: "open"},
+			},
+		},
+		{
+			name: "lets a single probe through after the cooldown",
+			steps: []step{
+				{action: "failure"}, {action: "failure"}, {action: "failure", wantState: "open"},
+				{action: "wait", wait: time.Minute, wantState: "open"},
+				{action: "allow", wantAllow: true, wantState: "half-open"},
+				{action: "allow", wantAllow: false, wantState: "half-open"},
+			},
+		},
+		{
+			name: "a successful probe closes the breaker",
+			steps: []step{
+				{action: "failure"}, {action: "failure"}, {action: "failure", wantState: "open"},
+				{action: "wait", wait: time.Minute, wantState: "open"},
+				{action: "allow", wantAllow: true, wantState: "half-open"},
+				{action: "success", wantState: "closed"},
+				{action: "allow", wantAllow: true, wantState: "closed"},
+			},
+		},
+		{
+			name: "a failed probe opens it again for a full cooldown",
+			steps: []step{
+				{action: "failure"}, {action: "failure"}, {action: "failure", wantState: "open"},
+				{action: "wait", wait: time.Minute, wantState: "open"},
+				{action: "allow", wantAllow: true, wantState: "half-open"},
+				{action: "failure", wantState: "open"},
+				{action: "allow", wantAllow: false, wantState: "open"},
+				{action: "wait", wait: time.Minute, wantState: "open"},
+				{action: "allow", wantAllow: true, wantState: "half-open"},
+			},
+		},
+		{
+			name: "an abandoned probe frees the slot",
+			steps: []step{
+				{action: "failure"}, {action: "failure"}, {action: "failure", wantState: "open"},
+				{action: "wait", wait: time.Minute, wantState: "open"},
+				{action: "allow", wantAllow: true, wantState: "half-open"},
+				{action: "release", wantState: "half-open"},
+				{action: "allow", wantAllow: true, wantState: "half-open"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1700000000, 0)
+			breaker := NewCircuitBreaker(3, time.Minute)
+			breaker.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				switch s.action {
+				case "allow":
+					if got := breaker.Allow(); got != s.wantAllow {
+						t.Fatalf("step %d: Allow() = %v, want %v", i, got, s.wantAllow)
+					}
+				case "success":
+					breaker.Success()
+				case "failure":
+					breaker.Failure()
+				case "release":
+					breaker.Release()
+				case "wait":
+					now = now.Add(s.wait)
+				}
+				if s.wantState != "" && breaker.State() != s.wantState {
+					t.Fatalf("step %d (%s): State() = %s, want %s", i, s.action, breaker.State(), s.wantState)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package musicinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/config"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+var (
+	ErrNotFound    = repository.ErrMusicInfoNotFound
+	ErrCircuitOpen = errors.New("music info API circuit breaker is open")
+	ErrUnavailable = errors.New("music info API unavailable")
+)
+
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API returned status: %d", e.StatusCode)
+}
+
+type HTTPClient struct {
+	baseURL        string
+	httpClient     *http.Client
+	maxRetries     int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	breaker        *CircuitBreaker
+	logger         *logger.Logger
+}
+
+func NewHTTPClient(cfg config.APIConfig, logger *logger.Logger) *HTTPClient {
+	return &HTTPClient{
+		baseURL:        cfg.MusicInfoURL,
+		httpClient:     &http.Client{Timeout: cfg.Timeout},
+		maxRetries:     cfg.MaxRetries,
+		retryBaseDelay: cfg.RetryBaseDelay,
+		retryMaxDelay:  cfg.RetryMaxDelay,
+		breaker:        NewCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+		logger:         logger,
+	}
+}
+
+func (c *HTTPClient) GetInfo(ctx context.Context, group, song string) (*entity.MusicInfo, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := c.backoff(attempt)
+			c.logger.Warn(ctx, "Retrying music info API request",
+				zap.Int("attempt", attempt),
+				zap.Duration("delay", delay),
+				zap.Error(lastErr))
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if !c.breaker.Allow() {
+			c.logger.Warn(ctx, "Music info API circuit breaker is open, failing fast")
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w: %v", ErrCircuitOpen, lastErr)
+			}
+			return nil, ErrCircuitOpen
+		}
+
+		info, err := c.fetch(ctx, group, song)
+		if err == nil {
+			c.breaker.Success()
+			return info, nil
+		}
+
+		if ctx.Err() != nil {
+			c.breaker.Release()
+			return nil, ctx.Err()
+		}
+
+		if !isRetryable(err) {
+			var statusErr *StatusError
+			if errors.Is(err, ErrNotFound) || errors.As(err, &statusErr) {
+				c.breaker.Success()
+			} else {
+				c.breaker.Failure()
+			}
+			return nil, err
+		}
+
+		c.breaker.Failure()
+		lastErr = err
+	}
+
+	c.logger.Error(ctx, "Music info API request failed after retries",
+		zap.Int("attempts", c.maxRetries+1),
+		zap.String("breaker_state", c.breaker.State()),
+		zap.Error(lastErr))
+	return nil, fmt.Errorf("%w after %d attempts: %v", ErrUnavailable, c.maxRetries+1, lastErr)
+}
+
+func (c *HTTPClient) fetch(ctx context.Context, group, song string) (*entity.MusicInfo, error) {
+	params := url.Values{}
+	params.Set("group", group)
+	params.Set("song", song)
+	endpoint := fmt.Sprintf("%s/info?%s", c.baseURL, params.Encode())
+
+	c.logger.Debug(ctx, "Sending request to external API",
+		zap.String("url", endpoint),
+		zap.String("group", group),
+		zap.String("song", song))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building API request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	var info entity.MusicInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	c.logger.Debug(ctx, "Successfully retrieved song information",
+		zap.Any("music_info", info))
+	return &info, nil
+}
+
+func (c *HTTPClient) backoff(attempt int) time.Duration {
+	delay := c.retryBaseDelay << (attempt - 1)
+	if delay <= 0 || (c.retryMaxDelay > 0 && delay > c.retryMaxDelay) {
+		delay = c.retryMaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay/2 + jitter
+}
+
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,206 @@
+package musicinfo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"song-library/internal/config"
+	"song-library/pkg/logger"
+)
+
+// stubServer answers the given status codes in turn, repeating the last one.
+func stubServer(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		if n >= len(statuses) {
+			n = len(statuses) - 1
+		}
+		if r.URL.Path != "/info" || r.URL.Query().Get("group") != "Muse" || r.URL.Query().Get("song") != "Uprising" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(statuses[n])
+		if statuses[n] == http.StatusOK {
+			w.Write([]byte(`{"releaseDate":"07.09.2009","text":"Paranoia is in bloom","link":"https://example.com"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newTestClient(url string, maxRetries, threshold int) *HTTPClient {
+	return NewHTTPClient(config.APIConfig{
+		MusicInfoURL:     url,
+		Timeout:          time.Second,
+		MaxRetries:       maxRetries,
+		RetryBaseDelay:   time.Millisecond,
+		RetryMaxDelay:    2 * time.Millisecond,
+		BreakerThreshold: threshold,
+		BreakerCooldown:  time.Minute,
+	}, logger.New("error"))
+}
+
+func TestGetInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []int
+		maxRetries  int
+		threshold   int
+		wantErr     error
+		wantStatus  int
+		wantCalls   int32
+		wantBreaker string
+	}{
+		{
+			name:        "success",
+			statuses:    []int{http.StatusOK},
+			maxRetries:  2,
+			threshold:   5,
+			wantCalls:   1,
+			wantBreaker: "closed",
+		},
+		{
+			name:        "retries server errors until one succeeds",
+			statuses:    []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:  2,
+			threshold:   5,
+			wantCalls:   3,
+			wantBreaker: "closed",
+		},
+		{
+			name:        "gives up after the retries",
+			statuses:    []int{http.StatusInternalServerError},
+			maxRetries:  2,
+			threshold:   5,
+			wantErr:     ErrUnavailable,
+			wantCalls:   3,
+			wantBreaker: "closed",
+		},
+		{
+			name:        "not found is not retried and does not count as a failure",
+			statuses:    []int{http.StatusNotFound},
+			maxRetries:  2,
+			threshold:   1,
+			wantErr:     ErrNotFound,
+			wantCalls:   1,
+			wantBreaker: "closed",
+		},
+		{
+			name:        "client errors are not retried",
+			statuses:    []int{http.StatusBadRequest},
+			maxRetries:  2,
+			threshold:   1,
+			wantStatus:  http.StatusBadRequest,
+			wantCalls:   1,
+			wantBreaker: "closed",
+		},
+		{
+			name:        "failures open the breaker and stop the retries",
+			statuses:    []int{http.StatusInternalServerError},
+			maxRetries:  5,
+			threshold:   2,
+			wantErr:     ErrCircuitOpen,
+			wantCalls:   2,
+			wantBreaker: "open",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := stubServer(t, tt.statuses...)
+			client := newTestClient(server.URL, tt.maxRetries, tt.threshold)
+
+			info, err := client.GetInfo(context.Background(), "Muse", "Uprising")
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetInfo() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantStatus != 0:
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.wantStatus {
+					t.Errorf("GetInfo() error = %v, want status %d", err, tt.wantStatus)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("GetInfo() error = %v", err)
+				}
+				if info.ReleaseDate != "07.09.2009" || info.Text != "Paranoia is in bloom" {
+					t.Errorf("GetInfo() = %+v", info)
+				}
+			}
+
+			if got := atomic.LoadInt32(calls); got != tt.wantCalls {
+				t.Errorf("upstream called %d times, want %d", got, tt.wantCalls)
+			}
+			if got := client.breaker.State(); got != tt.wantBreaker {
+				t.Errorf("breaker state = %s, want %s", got, tt.wantBreaker)
+			}
+		})
+	}
+}
+
+func TestGetInfoFailsFastWhileOpen(t *testing.T) {
+	server, calls := stubServer(t, http.StatusInternalServerError)
+	client := newTestClient(server.URL, 0, 1)
+
+	if _, err := client.GetInfo(context.Background(), "Muse", "Uprising"); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("first GetInfo() error = %v, want %v", err, ErrUnavailable)
+	}
+	if _, err := client.GetInfo(context.Background(), "Muse", "Uprising"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second GetInfo() error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("upstream called %d times, want 1", got)
+	}
+}
+
+func TestGetInfoStopsRetryingWhenCancelled(t *testing.T) {
+	server, calls := stubServer(t, http.StatusInternalServerError)
+	client := newTestClient(server.URL, 3, 10)
+	client.retryBaseDelay = time.Hour
+	client.retryMaxDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetInfo(ctx, "Muse", "Uprising"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetInfo() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("upstream called %d times, want 1", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	client := &HTTPClient{retryBaseDelay: 100 * time.Millisecond, retryMaxDelay: time.Second}
+
+	tests := []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{attempt: 1, base: 100 * time.Millisecond},
+		{attempt: 2, base: 200 * time.Millisecond},
+		{attempt: 3, base: 400 * time.Millisecond},
+		{attempt: 4, base: 800 * time.Millisecond},
+		{attempt: 5, base: time.Second},
+		{attempt: 40, base: time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			// Jitter keeps the delay between half the base delay and the base delay.
+			if got := client.backoff(tt.attempt); got < tt.base/2 || got > tt.base {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tt.attempt, got, tt.base/2, tt.base)
+			}
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+const albumColumns = `a.id, a.artist_id, ar.name, a.title, a.album_type, a.release_date, COALESCE(a.cover_link, ''),
+	(SELECT COUNT(*) FROM album_tracks t WHERE t.album_id = a.id), a.created_at, a.updated_at`
+
+type AlbumRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+func NewAlbumRepository(db *sql.DB, logger *logger.Logger) *AlbumRepository {
+	return &AlbumRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AlbumRepository) Create(ctx context.Context, album *entity.Album) error {
+	r.logger.Debug(ctx, "Starting album creation in DB",
+		zap.Int64("artist_id", album.ArtistID),
+		zap.String("title", album.Title))
+
+	query := `
+		WITH a AS (
+			INSERT INTO albums (artist_id, title, album_type, release_date, cover_link, created_at, updated_at)
+			VALUES ($1, btrim($2), $3, $4, NULLIF($5, ''), NOW(), NOW())
+			RETURNING *
+		)
+		SELECT ` + albumColumns + `
+		FROM a JOIN artists ar ON ar.id = a.artist_id`
+
+	created, err := scanAlbum(r.db.QueryRowContext(ctx, query,
+		album.ArtistID,
+		album.Title,
+		album.Type,
+		nullTime(album.ReleaseDate),
+		album.CoverLink,
+	))
+	if err != nil {
+		return r.mapWriteError(ctx, err, "Failed to create album in DB")
+	}
+
+	*album = *created
+	r.logger.Info(ctx, "Album successfully created in DB", zap.Int64("id", album.ID))
+	return nil
+}
+
+func (r *AlbumRepository) Update(ctx context.Context, album *entity.Album) error {
+	r.logger.Debug(ctx, "Starting album update in DB", zap.Int64("id", album.ID))
+
+	query := `
+		WITH a AS (
+			UPDATE albums
+			SET artist_id = $1, title = btrim($2), album_type = $3, release_date = $4,
+				cover_link = NULLIF($5, ''), updated_at = NOW()
+			WHERE id = $6
+			RETURNING *
+		)
+		SELECT ` + albumColumns + `
+		FROM a JOIN artists ar ON ar.id = a.artist_id`
+
+	updated, err := scanAlbum(r.db.QueryRowContext(ctx, query,
+		album.ArtistID,
+		album.Title,
+		album.Type,
+		nullTime(album.ReleaseDate),
+		album.CoverLink,
+		album.ID,
+	))
+	if err == sql.ErrNoRows {
+		return repository.ErrAlbumNotFound
+	}
+	if err != nil {
+		return r.mapWriteError(ctx, err, "Failed to update album in DB")
+	}
+
+	*album = *updated
+	r.logger.Info(ctx, "Album successfully updated in DB", zap.Int64("id", album.ID))
+	return nil
+}
+
+func (r *AlbumRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM albums WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting album: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return repository.ErrAlbumNotFound
+	}
+
+	return nil
+}
+
+func (r *AlbumRepository) GetByID(ctx context.Context, id int64) (*entity.Album, error) {
+	query := `
+		SELECT ` + albumColumns + `
+		FROM albums a JOIN artists ar ON ar.id = a.artist_id
+		WHERE a.id = $1`
+
+	album, err := scanAlbum(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrAlbumNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting album: %w", err)
+	}
+
+	return album, nil
+}
+
+func (r *AlbumRepository) List(ctx context.Context, filter *entity.AlbumFilter) ([]*entity.Album, int, error) {
+	r.logger.Debug(ctx, "Starting album list retrieval", zap.Any("filter", filter))
+
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if filter.ArtistID != 0 {
+		conditions = append(conditions, fmt.Sprintf("a.artist_id = $%d", argNum))
+		args = append(args, filter.ArtistID)
+		argNum++
+	}
+	if filter.Title != "" {
+		conditions = append(conditions, fmt.Sprintf("a.title ILIKE $%d", argNum))
+		args = append(args, "%"+filter.Title+"%")
+		argNum++
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, fmt.Sprintf("a.album_type = $%d", argNum))
+		args = append(args, filter.Type)
+		argNum++
+	}
+
+	query := `SELECT ` + albumColumns + `
+			  FROM albums a JOIN artists ar ON ar.id = a.artist_id WHERE 1=1`
+	countQuery := `SELECT COUNT(*) FROM albums a WHERE 1=1`
+
+	if len(conditions) > 0 {
+		condStr := strings.Join(conditions, " AND ")
+		query += " AND " + condStr
+		countQuery += " AND " + condStr
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		r.logger.Error(ctx, "Failed to count albums", zap.Error(err))
+		return nil, 0, fmt.Errorf("error counting total records: %w", err)
+	}
+
+	query += fmt.Sprintf(" ORDER BY a.release_date NULLS LAST, a.id LIMIT $%d OFFSET $%d", argNum, argNum+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
+		return nil, 0, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []*entity.Album
+	for rows.Next() {
+		album, err := scanAlbum(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			return nil, 0, fmt.Errorf("error scanning result: %w", err)
+		}
+		albums = append(albums, album)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	r.logger.Info(ctx, "Album list successfully retrieved",
+		zap.Int("total", total),
+		zap.Int("retrieved", len(albums)))
+	return albums, total, nil
+}
+
+// SetTrack adds a song to the album or moves it to a new disc/track position.
+func (r *AlbumRepository) SetTrack(ctx context.Context, track *entity.AlbumTrack) error {
+	query := `
+		INSERT INTO album_tracks (album_id, song_id, disc_number, track_number)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (SELECT 1 FROM songs WHERE id = $2 AND deleted_at IS NOT NULL)
+		ON CONFLICT (album_id, song_id)
+		DO UPDATE SET disc_number = EXCLUDED.disc_number, track_number = EXCLUDED.track_number`
+
+	result, err := r.db.ExecContext(ctx, query, track.AlbumID, track.SongID, track.DiscNumber, track.TrackNumber)
+	if err != nil {
+		switch {
+		case isPgError(err, pgUniqueViolation):
+			return repository.ErrTrackPositionTaken
+		case isPgError(err, pgForeignKeyViolation) && pgConstraint(err) == "album_tracks_song_id_fkey":
+			return repository.ErrSongNotFound
+		case isPgError(err, pgForeignKeyViolation):
+			return repository.ErrAlbumNotFound
+		}
+		r.logger.Error(ctx, "Failed to set album track", zap.Error(err))
+		return fmt.Errorf("error setting album track: %w", err)
+	}
+
+	// Songs in the trash cannot be added.
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+	if rows == 0 {
+		return repository.ErrSongNotFound
+	}
+
+	return nil
+}
+
+func (r *AlbumRepository) RemoveTrack(ctx context.Context, albumID, songID int64) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM album_tracks WHERE album_id = $1 AND song_id = $2`, albumID, songID)
+	if err != nil {
+		return fmt.Errorf("error removing album track: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return repository.ErrTrackNotFound
+	}
+
+	return nil
+}
+
+func (r *AlbumRepository) ListTracks(ctx context.Context, albumID int64) ([]*entity.AlbumTrack, error) {
+	query := `
+		SELECT t.album_id, t.disc_number, t.track_number, ` + songColumns + `
+		FROM album_tracks t
+		JOIN songs ON songs.id = t.song_id
+		` + songStatsJoin + `
+		WHERE t.album_id = $1 AND songs.deleted_at IS NULL
+		ORDER BY t.disc_number, t.track_number`
+
+	rows, err := r.db.QueryContext(ctx, query, albumID)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var tracks []*entity.AlbumTrack
+	for rows.Next() {
+		track := &entity.AlbumTrack{}
+		song, err := scanSong(withPrefix(rows, &track.AlbumID, &track.DiscNumber, &track.TrackNumber))
+		if err != nil {
+			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			return nil, fmt.Errorf("error scanning result: %w", err)
+		}
+		track.SongID = song.ID
+		track.Song = song
+		tracks = append(tracks, track)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	return tracks, nil
+}
+
+func (r *AlbumRepository) mapWriteError(ctx context.Context, err error, message string) error {
+	switch {
+	case isPgError(err, pgUniqueViolation):
+		return repository.ErrAlbumAlreadyExists
+	case isPgError(err, pgForeignKeyViolation):
+		return repository.ErrArtistNotFound
+	}
+	r.logger.Error(ctx, message, zap.Error(err))
+	return fmt.Errorf("error saving album: %w", err)
+}
+
+func scanAlbum(row rowScanner) (*entity.Album, error) {
+	album := &entity.Album{}
+	var releaseDate sql.NullTime
+
+	err := row.Scan(
+		&album.ID,
+		&album.ArtistID,
+		&album.ArtistName,
+		&album.Title,
+		&album.Type,
+		&releaseDate,
+		&album.CoverLink,
+		&album.TrackCount,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if releaseDate.Valid {
+		album.ReleaseDate = releaseDate.Time
+	}
+	return album, nil
+}
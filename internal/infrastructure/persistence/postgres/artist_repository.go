@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+const artistColumns = `id, name, COALESCE(country, ''), COALESCE(formed_year, 0), COALESCE(description, ''),
+	created_at, updated_at`
+
+type ArtistRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+func NewArtistRepository(db *sql.DB, logger *logger.Logger) *ArtistRepository {
+	return &ArtistRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ArtistRepository) Create(ctx context.Context, artist *entity.Artist) error {
+	r.logger.Debug(ctx, "Starting artist creation in DB", zap.String("name", artist.Name))
+
+	query := `
+		INSERT INTO artists (name, country, formed_year, description, created_at, updated_at)
+		VALUES (btrim(regexp_replace($1, '\s+', ' ', 'g')), NULLIF($2, ''), NULLIF($3, 0), NULLIF($4, ''), NOW(), NOW())
+		RETURNING id, name, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		artist.Name,
+		artist.Country,
+		artist.FormedYear,
+		artist.Description,
+	).Scan(&artist.ID, &artist.Name, &artist.CreatedAt, &artist.UpdatedAt)
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return repository.ErrArtistAlreadyExists
+		}
+		r.logger.Error(ctx, "Failed to create artist in DB", zap.Error(err))
+		return fmt.Errorf("failed to create artist: %w", err)
+	}
+
+	r.logger.Info(ctx, "Artist successfully created in DB", zap.Int64("id", artist.ID))
+	return nil
+}
+
+// Update renames the artist together with the denormalised group name of
+// every song that references it.
+func (r *ArtistRepository) Update(ctx context.Context, artist *entity.Artist) error {
+	r.logger.Debug(ctx, "Starting artist update in DB", zap.Int64("id", artist.ID))
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE artists
+		SET name = btrim(regexp_replace($1, '\s+', ' ', 'g')), country = NULLIF($2, ''),
+			formed_year = NULLIF($3, 0), description = NULLIF($4, ''), updated_at = NOW()
+		WHERE id = $5
+		RETURNING name, created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		artist.Name,
+		artist.Country,
+		artist.FormedYear,
+		artist.Description,
+		artist.ID,
+	).Scan(&artist.Name, &artist.CreatedAt, &artist.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return repository.ErrArtistNotFound
+	}
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return repository.ErrArtistAlreadyExists
+		}
+		r.logger.Error(ctx, "Failed to update artist in DB", zap.Error(err))
+		return fmt.Errorf("error updating artist: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE songs SET group_name = $1, updated_at = NOW(), version = version + 1
+		 WHERE artist_id = $2 AND group_name <> $1`,
+		artist.Name, artist.ID)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to propagate artist name to songs", zap.Error(err))
+		return fmt.Errorf("error updating artist songs: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	r.logger.Info(ctx, "Artist successfully updated in DB", zap.Int64("id", artist.ID))
+	return nil
+}
+
+func (r *ArtistRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM artists WHERE id = $1`, id)
+	if err != nil {
+		if isPgError(err, pgForeignKeyViolation) {
+			return repository.ErrArtistInUse
+		}
+		return fmt.Errorf("error deleting artist: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return repository.ErrArtistNotFound
+	}
+
+	return nil
+}
+
+func (r *ArtistRepository) GetByID(ctx context.Context, id int64) (*entity.Artist, error) {
+	query := `SELECT ` + artistColumns + ` FROM artists WHERE id = $1`
+
+	artist, err := scanArtist(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrArtistNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting artist: %w", err)
+	}
+
+	return artist, nil
+}
+
+func (r *ArtistRepository) List(ctx context.Context, filter *entity.ArtistFilter) ([]*entity.Artist, int, error) {
+	r.logger.Debug(ctx, "Starting artist list retrieval", zap.Any("filter", filter))
+
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if filter.Name != "" {
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", argNum))
+		args = append(args, "%"+filter.Name+"%")
+		argNum++
+	}
+	if filter.Country != "" {
+		conditions = append(conditions, fmt.Sprintf("country ILIKE $%d", argNum))
+		args = append(args, filter.Country)
+		argNum++
+	}
+
+	query := `SELECT ` + artistColumns + ` FROM artists WHERE 1=1`
+	countQuery := `SELECT COUNT(*) FROM artists WHERE 1=1`
+
+	if len(conditions) > 0 {
+		condStr := strings.Join(conditions, " AND ")
+		query += " AND " + condStr
+		countQuery += " AND " + condStr
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		r.logger.Error(ctx, "Failed to count artists", zap.Error(err))
+		return nil, 0, fmt.Errorf("error counting total records: %w", err)
+	}
+
+	query += fmt.Sprintf(" ORDER BY name, id LIMIT $%d OFFSET $%d", argNum, argNum+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
+		return nil, 0, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var artists []*entity.Artist
+	for rows.Next() {
+		artist, err := scanArtist(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			return nil, 0, fmt.Errorf("error scanning result: %w", err)
+		}
+		artists = append(artists, artist)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	r.logger.Info(ctx, "Artist list successfully retrieved",
+		zap.Int("total", total),
+		zap.Int("retrieved", len(artists)))
+	return artists, total, nil
+}
+
+func scanArtist(row rowScanner) (*entity.Artist, error) {
+	artist := &entity.Artist{}
+	err := row.Scan(
+		&artist.ID,
+		&artist.Name,
+		&artist.Country,
+		&artist.FormedYear,
+		&artist.Description,
+		&artist.CreatedAt,
+		&artist.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return artist, nil
+}
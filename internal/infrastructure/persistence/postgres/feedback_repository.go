@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+const songStatsColumns = `st.song_id, st.favorite_count, st.rating_count, st.average_rating, st.play_count`
+
+// songStatsRecount sets the aggregates of song $1 from the feedback tables,
+// for when rows have been moved over to it wholesale.
+const songStatsRecount = `
+	UPDATE song_stats
+	SET favorite_count = (SELECT COUNT(*) FROM song_favorites WHERE song_id = $1),
+		rating_count = (SELECT COUNT(*) FROM song_ratings WHERE song_id = $1),
+		rating_sum = (SELECT COALESCE(SUM(rating), 0) FROM song_ratings WHERE song_id = $1),
+		play_count = (SELECT COUNT(*) FROM song_plays WHERE song_id = $1)
+	WHERE song_id = $1`
+
+type FeedbackRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+func NewFeedbackRepository(db *sql.DB, logger *logger.Logger) *FeedbackRepository {
+	return &FeedbackRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *FeedbackRepository) GetFeedback(ctx context.Context, userID, songID int64) (*entity.SongFeedback, error) {
+	feedback := &entity.SongFeedback{SongID: songID}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT `+songStatsColumns+`,
+			EXISTS (SELECT 1 FROM song_favorites WHERE user_id = $2 AND song_id = songs.id),
+			COALESCE((SELECT rating FROM song_ratings WHERE user_id = $2 AND song_id = songs.id), 0)
+		FROM songs `+songStatsJoin+`
+		WHERE id = $1 AND deleted_at IS NULL`,
+		songID, userID).Scan(
+		&feedback.Stats.SongID,
+		&feedback.Stats.FavoriteCount,
+		&feedback.Stats.RatingCount,
+		&feedback.Stats.AverageRating,
+		&feedback.Stats.PlayCount,
+		&feedback.Favorite,
+		&feedback.Rating,
+	)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrSongNotFound
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to get song feedback", zap.Error(err))
+		return nil, fmt.Errorf("error getting song feedback: %w", err)
+	}
+	return feedback, nil
+}
+
+func (r *FeedbackRepository) AddFavorite(ctx context.Context, userID, songID int64) (*entity.SongStats, error) {
+	return r.change(ctx, songID, func(tx *sql.Tx) (string, []interface{}, error) {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO song_favorites (user_id, song_id, created_at)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT DO NOTHING`,
+			userID, songID)
+		if err != nil {
+			return "", nil, fmt.Errorf("error adding favorite: %w", err)
+		}
+		added, err := result.RowsAffected()
+		if err != nil {
+			return "", nil, fmt.Errorf("error getting affected rows: %w", err)
+		}
+		return "favorite_count = favorite_count + $2", []interface{}{added}, nil
+	})
+}
+
+func (r *FeedbackRepository) RemoveFavorite(ctx context.Context, userID, songID int64) (*entity.SongStats, error) {
+	return r.change(ctx, songID, func(tx *sql.Tx) (string, []interface{}, error) {
+		result, err := tx.ExecContext(ctx,
+			`DELETE FROM song_favorites WHERE user_id = $1 AND song_id = $2`, userID, songID)
+		if err != nil {
+			return "", nil, fmt.Errorf("error removing favorite: %w", err)
+		}
+		removed, err := result.RowsAffected()
+		if err != nil {
+			return "", nil, fmt.Errorf("error getting affected rows: %w", err)
+		}
+		return "favorite_count = favorite_count - $2", []interface{}{removed}, nil
+	})
+}
+
+func (r *FeedbackRepository) ListFavorites(ctx context.Context, userID int64, page, pageSize int) ([]*entity.Song, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM song_favorites f JOIN songs s ON s.id = f.song_id
+		WHERE f.user_id = $1 AND s.deleted_at IS NULL`, userID).Scan(&total)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to count favorites", zap.Error(err))
+		return nil, 0, fmt.Errorf("error counting total records: %w", err)
+	}
+
+	query := `
+		SELECT ` + songColumns + `
+		FROM (
+			SELECT song_id AS favorite_song_id, created_at AS favorited_at
+			FROM song_favorites
+			WHERE user_id = $1
+		) f
+		JOIN songs ON songs.id = f.favorite_song_id
+		` + songStatsJoin + `
+		WHERE deleted_at IS NULL
+		ORDER BY f.favorited_at DESC, id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
+		return nil, 0, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var songs []*entity.Song
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			return nil, 0, fmt.Errorf("error scanning result: %w", err)
+		}
+		songs = append(songs, song)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	return songs, total, nil
+}
+
+func (r *FeedbackRepository) Rate(ctx context.Context, userID, songID int64, rating int) (*entity.SongStats, error) {
+	return r.change(ctx, songID, func(tx *sql.Tx) (string, []interface{}, error) {
+		var previous int
+		err := tx.QueryRowContext(ctx,
+			`SELECT rating FROM song_ratings WHERE user_id = $1 AND song_id = $2`, userID, songID).Scan(&previous)
+		if err != nil && err != sql.ErrNoRows {
+			return "", nil, fmt.Errorf("error getting rating: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO song_ratings (user_id, song_id, rating, created_at, updated_at)
+			VALUES ($1, $2, $3, NOW(), NOW())
+			ON CONFLICT (user_id, song_id) DO UPDATE SET rating = EXCLUDED.rating, updated_at = NOW()`,
+			userID, songID, rating)
+		if err != nil {
+			return "", nil, fmt.Errorf("error saving rating: %w", err)
+		}
+
+		added := 0
+		if previous == 0 {
+			added = 1
+		}
+		return "rating_count = rating_count + $2, rating_sum = rating_sum + $3", []interface{}{added, rating - previous}, nil
+	})
+}
+
+func (r *FeedbackRepository) RemoveRating(ctx context.Context, userID, songID int64) (*entity.SongStats, error) {
+	return r.change(ctx, songID, func(tx *sql.Tx) (string, []interface{}, error) {
+		var previous int
+		err := tx.QueryRowContext(ctx,
+			`DELETE FROM song_ratings WHERE user_id = $1 AND song_id = $2 RETURNING rating`, userID, songID).Scan(&previous)
+		if err != nil && err != sql.ErrNoRows {
+			return "", nil, fmt.Errorf("error removing rating: %w", err)
+		}
+
+		removed := 0
+		if previous != 0 {
+			removed = 1
+		}
+		return "rating_count = rating_count - $2, rating_sum = rating_sum - $3", []interface{}{removed, previous}, nil
+	})
+}
+
+// RecordPlay inserts the play and bumps the counter in one statement; plays
+// need no lock beyond the one the update takes on the song's stats.
+func (r *FeedbackRepository) RecordPlay(ctx context.Context, songID, userID int64) (*entity.SongStats, error) {
+	stats, err := scanSongStats(r.db.QueryRowContext(ctx, `
+		WITH played AS (
+			INSERT INTO song_plays (song_id, user_id, played_at)
+			SELECT id, NULLIF($2::int, 0), NOW()
+			FROM songs
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING song_id
+		)
+		UPDATE song_stats st SET play_count = play_count + 1
+		WHERE song_id IN (SELECT song_id FROM played)
+		RETURNING `+songStatsColumns,
+		songID, userID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrSongNotFound
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to record play in DB", zap.Error(err))
+		return nil, fmt.Errorf("error recording play: %w", err)
+	}
+
+	r.logger.Debug(ctx, "Play successfully recorded in DB", zap.Int64("song_id", songID))
+	return stats, nil
+}
+
+// change runs a favorite or rating change while holding the song's stats
+// row, so that the change and the aggregate update it returns cannot
+// interleave with another change of the same song. The song row itself is
+// only read, so feedback never waits for edits or enrichment of the song. The SET clause apply returns refers to
+// the arguments it returns as $2 onwards.
+func (r *FeedbackRepository) change(ctx context.Context, songID int64, apply func(tx *sql.Tx) (string, []interface{}, error)) (*entity.SongStats, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT st.song_id
+		FROM songs `+songStatsJoin+`
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR NO KEY UPDATE OF st`, songID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrSongNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error locking song: %w", err)
+	}
+
+	set, args, err := apply(tx)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to change song feedback in DB", zap.Int64("song_id", songID), zap.Error(err))
+		return nil, err
+	}
+
+	stats, err := scanSongStats(tx.QueryRowContext(ctx,
+		`UPDATE song_stats st SET `+set+` WHERE song_id = $1 RETURNING `+songStatsColumns,
+		append([]interface{}{songID}, args...)...))
+	if err != nil {
+		return nil, fmt.Errorf("error updating song stats: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+	return stats, nil
+}
+
+func scanSongStats(row rowScanner) (*entity.SongStats, error) {
+	stats := &entity.SongStats{}
+	err := row.Scan(
+		&stats.SongID,
+		&stats.FavoriteCount,
+		&stats.RatingCount,
+		&stats.AverageRating,
+		&stats.PlayCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,616 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+// playlistColumns select from a playlist "p" joined with its owner "u". The
+// item count leaves out songs in the trash, like ListItems.
+const playlistColumns = `p.id, p.user_id, u.username, p.name, COALESCE(p.description, ''), p.visibility,
+	(SELECT COUNT(*) FROM playlist_items i JOIN songs s ON s.id = i.song_id
+	 WHERE i.playlist_id = p.id AND s.deleted_at IS NULL),
+	p.created_at, p.updated_at`
+
+// playlistItemsQuery numbers the visible items of playlist $1 in order. It
+// exposes item_id, song_id, added_at and position, none of which clash with
+// the columns of songs it is joined with.
+const playlistItemsQuery = `
+	SELECT pi.id AS item_id, pi.song_id, pi.added_at,
+		row_number() OVER (ORDER BY pi.rank, pi.id) AS position
+	FROM playlist_items pi
+	JOIN songs s ON s.id = pi.song_id
+	WHERE pi.playlist_id = $1 AND s.deleted_at IS NULL`
+
+// rankGap is the distance between the ranks of neighbouring items after they
+// are numbered, leaving room for items placed between them later.
+const rankGap = 1 << 16
+
+type PlaylistRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+func NewPlaylistRepository(db *sql.DB, logger *logger.Logger) *PlaylistRepository {
+	return &PlaylistRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PlaylistRepository) Create(ctx context.Context, playlist *entity.Playlist) error {
+	r.logger.Debug(ctx, "Starting playlist creation in DB", zap.Int64("user_id", playlist.UserID))
+
+	query := `
+		WITH p AS (
+			INSERT INTO playlists (user_id, name, description, visibility, created_at, updated_at)
+			VALUES ($1, $2, NULLIF($3, ''), $4, NOW(), NOW())
+			RETURNING *
+		)
+		SELECT ` + playlistColumns + `
+		FROM p JOIN users u ON u.id = p.user_id`
+
+	created, err := scanPlaylist(r.db.QueryRowContext(ctx, query,
+		playlist.UserID,
+		playlist.Name,
+		playlist.Description,
+		string(playlist.Visibility),
+	))
+	if err != nil {
+		r.logger.Error(ctx, "Failed to create playlist in DB", zap.Error(err))
+		return fmt.Errorf("failed to create playlist: %w", err)
+	}
+	*playlist = *created
+
+	r.logger.Info(ctx, "Playlist successfully created in DB", zap.Int64("id", playlist.ID))
+	return nil
+}
+
+func (r *PlaylistRepository) Update(ctx context.Context, playlist *entity.Playlist) error {
+	r.logger.Debug(ctx, "Starting playlist update in DB", zap.Int64("id", playlist.ID))
+
+	query := `
+		WITH p AS (
+			UPDATE playlists
+			SET name = $1, description = NULLIF($2, ''), visibility = $3, updated_at = NOW()
+			WHERE id = $4
+			RETURNING *
+		)
+		SELECT ` + playlistColumns + `
+		FROM p JOIN users u ON u.id = p.user_id`
+
+	updated, err := scanPlaylist(r.db.QueryRowContext(ctx, query,
+		playlist.Name,
+		playlist.Description,
+		string(playlist.Visibility),
+		playlist.ID,
+	))
+	if err == sql.ErrNoRows {
+		return repository.ErrPlaylistNotFound
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to update playlist in DB", zap.Error(err))
+		return fmt.Errorf("error updating playlist: %w", err)
+	}
+	*playlist = *updated
+
+	r.logger.Info(ctx, "Playlist successfully updated in DB", zap.Int64("id", playlist.ID))
+	return nil
+}
+
+func (r *PlaylistRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM playlists WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting playlist: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return repository.ErrPlaylistNotFound
+	}
+
+	return nil
+}
+
+func (r *PlaylistRepository) GetByID(ctx context.Context, id int64) (*entity.Playlist, error) {
+	query := `SELECT ` + playlistColumns + ` FROM playlists p JOIN users u ON u.id = p.user_id WHERE p.id = $1`
+
+	playlist, err := scanPlaylist(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrPlaylistNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting playlist: %w", err)
+	}
+
+	return playlist, nil
+}
+
+func (r *PlaylistRepository) List(ctx context.Context, filter *entity.PlaylistFilter) ([]*entity.Playlist, int, error) {
+	r.logger.Debug(ctx, "Starting playlist list retrieval", zap.Any("filter", filter))
+
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if !filter.AllVisible {
+		conditions = append(conditions, fmt.Sprintf("(p.visibility = 'public' OR p.user_id = $%d)", argNum))
+		args = append(args, filter.ViewerID)
+		argNum++
+	}
+	if filter.Name != "" {
+		conditions = append(conditions, fmt.Sprintf("p.name ILIKE $%d", argNum))
+		args = append(args, "%"+filter.Name+"%")
+		argNum++
+	}
+	if filter.UserID != 0 {
+		conditions = append(conditions, fmt.Sprintf("p.user_id = $%d", argNum))
+		args = append(args, filter.UserID)
+		argNum++
+	}
+
+	query := `SELECT ` + playlistColumns + ` FROM playlists p JOIN users u ON u.id = p.user_id WHERE 1=1`
+	countQuery := `SELECT COUNT(*) FROM playlists p WHERE 1=1`
+
+	if len(conditions) > 0 {
+		condStr := strings.Join(conditions, " AND ")
+		query += " AND " + condStr
+		countQuery += " AND " + condStr
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		r.logger.Error(ctx, "Failed to count playlists", zap.Error(err))
+		return nil, 0, fmt.Errorf("error counting total records: %w", err)
+	}
+
+	query += fmt.Sprintf(" ORDER BY p.updated_at DESC, p.id DESC LIMIT $%d OFFSET $%d", argNum, argNum+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
+		return nil, 0, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var playlists []*entity.Playlist
+	for rows.Next() {
+		playlist, err := scanPlaylist(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			return nil, 0, fmt.Errorf("error scanning result: %w", err)
+		}
+		playlists = append(playlists, playlist)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	r.logger.Info(ctx, "Playlist list successfully retrieved",
+		zap.Int("total", total),
+		zap.Int("retrieved", len(playlists)))
+	return playlists, total, nil
+}
+
+func (r *PlaylistRepository) ListItems(ctx context.Context, playlistID int64, withLyrics bool, page, pageSize int) ([]*entity.PlaylistItem, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+playlistItemsQuery+`) i`, playlistID).Scan(&total)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to count playlist items", zap.Error(err))
+		return nil, 0, fmt.Errorf("error counting total records: %w", err)
+	}
+
+	query := `
+		SELECT i.item_id, i.position, i.added_at, ` + playlistSongColumns(withLyrics) + `
+		FROM (` + playlistItemsQuery + `) i
+		JOIN songs ON songs.id = i.song_id
+		` + songStatsJoin + `
+		ORDER BY i.position
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, playlistID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
+		return nil, 0, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*entity.PlaylistItem
+	for rows.Next() {
+		item, err := scanPlaylistItem(rows, playlistID)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			return nil, 0, fmt.Errorf("error scanning result: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	return items, total, nil
+}
+
+// AddItem appends the song or places it at the anchor. Edits of the same
+// playlist are serialised by locking its row.
+func (r *PlaylistRepository) AddItem(ctx context.Context, item *entity.PlaylistItem, anchor entity.PlaylistAnchor) error {
+	r.logger.Debug(ctx, "Starting playlist item creation in DB",
+		zap.Int64("playlist_id", item.PlaylistID),
+		zap.Int64("song_id", item.SongID))
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockPlaylist(ctx, tx, item.PlaylistID); err != nil {
+		return err
+	}
+
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1 AND deleted_at IS NULL)`, item.SongID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("error checking song: %w", err)
+	}
+	if !exists {
+		return repository.ErrSongNotFound
+	}
+
+	rank, err := itemRank(ctx, tx, item.PlaylistID, 0, anchor)
+	if err != nil {
+		return err
+	}
+
+	var itemID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO playlist_items (playlist_id, song_id, rank, added_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING id`,
+		item.PlaylistID, item.SongID, rank).Scan(&itemID)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to create playlist item in DB", zap.Error(err))
+		return fmt.Errorf("error adding playlist item: %w", err)
+	}
+
+	if err := touchPlaylist(ctx, tx, item.PlaylistID); err != nil {
+		return err
+	}
+
+	added, err := getPlaylistItem(ctx, tx, item.PlaylistID, itemID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	*item = *added
+
+	r.logger.Info(ctx, "Playlist item successfully created in DB",
+		zap.Int64("playlist_id", item.PlaylistID),
+		zap.Int64("id", item.ID))
+	return nil
+}
+
+// AppendItems adds the songs after the last item in one transaction, rankGap
+// apart like freshly numbered items.
+func (r *PlaylistRepository) AppendItems(ctx context.Context, playlistID int64, songIDs []int64) ([]int64, error) {
+	r.logger.Debug(ctx, "Starting playlist items creation in DB",
+		zap.Int64("playlist_id", playlistID),
+		zap.Int("songs", len(songIDs)))
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockPlaylist(ctx, tx, playlistID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		INSERT INTO playlist_items (playlist_id, song_id, rank, added_at)
+		SELECT $1, song.id,
+			(SELECT COALESCE(MAX(rank), 0) FROM playlist_items WHERE playlist_id = $1) + song.n * $3,
+			NOW()
+		FROM unnest($2::bigint[]) WITH ORDINALITY AS song(id, n)
+		RETURNING id, rank`,
+		playlistID, pq.Array(songIDs), rankGap)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to create playlist items in DB", zap.Error(err))
+		return nil, fmt.Errorf("error adding playlist items: %w", err)
+	}
+	defer rows.Close()
+
+	// RETURNING gives no order, but the ranks follow the order of the songs.
+	type added struct{ id, rank int64 }
+	items := make([]added, 0, len(songIDs))
+	for rows.Next() {
+		var item added
+		if err := rows.Scan(&item.id, &item.rank); err != nil {
+			return nil, fmt.Errorf("error scanning result: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].rank < items[j].rank })
+	itemIDs := make([]int64, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.id
+	}
+
+	if err := touchPlaylist(ctx, tx, playlistID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	r.logger.Info(ctx, "Playlist items successfully created in DB",
+		zap.Int64("playlist_id", playlistID),
+		zap.Int("created", len(itemIDs)))
+	return itemIDs, nil
+}
+
+func (r *PlaylistRepository) MoveItem(ctx context.Context, playlistID, itemID int64, anchor entity.PlaylistAnchor) (*entity.PlaylistItem, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockPlaylist(ctx, tx, playlistID); err != nil {
+		return nil, err
+	}
+	if _, err := getPlaylistItem(ctx, tx, playlistID, itemID); err != nil {
+		return nil, err
+	}
+
+	rank, err := itemRank(ctx, tx, playlistID, itemID, anchor)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE playlist_items SET rank = $1 WHERE id = $2`, rank, itemID)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to move playlist item in DB", zap.Error(err))
+		return nil, fmt.Errorf("error moving playlist item: %w", err)
+	}
+
+	if err := touchPlaylist(ctx, tx, playlistID); err != nil {
+		return nil, err
+	}
+
+	item, err := getPlaylistItem(ctx, tx, playlistID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	r.logger.Info(ctx, "Playlist item successfully moved in DB",
+		zap.Int64("playlist_id", playlistID),
+		zap.Int64("id", itemID),
+		zap.Int("position", item.Position))
+	return item, nil
+}
+
+func (r *PlaylistRepository) RemoveItem(ctx context.Context, playlistID, itemID int64) error {
+	result, err := r.db.ExecContext(ctx, `
+		WITH removed AS (
+			DELETE FROM playlist_items WHERE id = $1 AND playlist_id = $2
+			RETURNING playlist_id
+		)
+		UPDATE playlists SET updated_at = NOW() WHERE id IN (SELECT playlist_id FROM removed)`,
+		itemID, playlistID)
+	if err != nil {
+		return fmt.Errorf("error removing playlist item: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return repository.ErrPlaylistItemNotFound
+	}
+
+	return nil
+}
+
+func lockPlaylist(ctx context.Context, tx *sql.Tx, playlistID int64) error {
+	var id int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM playlists WHERE id = $1 FOR UPDATE`, playlistID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return repository.ErrPlaylistNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error locking playlist: %w", err)
+	}
+	return nil
+}
+
+func touchPlaylist(ctx context.Context, tx *sql.Tx, playlistID int64) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE playlists SET updated_at = NOW() WHERE id = $1`, playlistID); err != nil {
+		return fmt.Errorf("error updating playlist: %w", err)
+	}
+	return nil
+}
+
+// itemRank returns the rank that places an item at the anchor, ignoring the
+// item being moved (zero when adding). When two neighbours have no rank left
+// between them, the playlist is numbered afresh first.
+func itemRank(ctx context.Context, tx *sql.Tx, playlistID, movingID int64, anchor entity.PlaylistAnchor) (int64, error) {
+	for renumbered := false; ; renumbered = true {
+		low, high, err := anchorBounds(ctx, tx, playlistID, movingID, anchor)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case low == nil && high == nil:
+			return rankGap, nil
+		case high == nil:
+			return *low + rankGap, nil
+		case low == nil:
+			return *high - rankGap, nil
+		case *high-*low >= 2:
+			return *low + (*high-*low)/2, nil
+		}
+
+		if renumbered {
+			return 0, fmt.Errorf("no rank left between playlist items after renumbering")
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE playlist_items pi
+			SET rank = numbered.n * $2
+			FROM (
+				SELECT id, row_number() OVER (ORDER BY rank, id) AS n
+				FROM playlist_items
+				WHERE playlist_id = $1
+			) numbered
+			WHERE pi.id = numbered.id`,
+			playlistID, rankGap)
+		if err != nil {
+			return 0, fmt.Errorf("error renumbering playlist items: %w", err)
+		}
+	}
+}
+
+// anchorBounds returns the ranks of the items the new position lies between;
+// nil stands for the start or the end of the playlist.
+func anchorBounds(ctx context.Context, tx *sql.Tx, playlistID, movingID int64, anchor entity.PlaylistAnchor) (*int64, *int64, error) {
+	if anchor.AfterItemID == 0 && anchor.BeforeItemID == 0 {
+		var last sql.NullInt64
+		err := tx.QueryRowContext(ctx,
+			`SELECT MAX(rank) FROM playlist_items WHERE playlist_id = $1 AND id <> $2`,
+			playlistID, movingID).Scan(&last)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting last playlist item: %w", err)
+		}
+		if !last.Valid {
+			return nil, nil, nil
+		}
+		return &last.Int64, nil, nil
+	}
+
+	anchorID, neighbour := anchor.AfterItemID, `(rank, id) > ($3, $4) ORDER BY rank, id`
+	if anchor.BeforeItemID != 0 {
+		anchorID, neighbour = anchor.BeforeItemID, `(rank, id) < ($3, $4) ORDER BY rank DESC, id DESC`
+	}
+
+	var anchorRank int64
+	err := tx.QueryRowContext(ctx,
+		`SELECT rank FROM playlist_items WHERE id = $1 AND playlist_id = $2`,
+		anchorID, playlistID).Scan(&anchorRank)
+	if err == sql.ErrNoRows {
+		return nil, nil, repository.ErrPlaylistItemNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting anchor item: %w", err)
+	}
+
+	var next *int64
+	var rank int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT rank FROM playlist_items
+		WHERE playlist_id = $1 AND id <> $2 AND `+neighbour+`
+		LIMIT 1`,
+		playlistID, movingID, anchorRank, anchorID).Scan(&rank)
+	switch {
+	case err == nil:
+		next = &rank
+	case err != sql.ErrNoRows:
+		return nil, nil, fmt.Errorf("error getting neighbouring item: %w", err)
+	}
+
+	if anchor.BeforeItemID != 0 {
+		return next, &anchorRank, nil
+	}
+	return &anchorRank, next, nil
+}
+
+// getPlaylistItem loads a visible item with its song and position.
+func getPlaylistItem(ctx context.Context, tx *sql.Tx, playlistID, itemID int64) (*entity.PlaylistItem, error) {
+	query := `
+		SELECT i.item_id, i.position, i.added_at, ` + playlistSongColumns(false) + `
+		FROM (` + playlistItemsQuery + `) i
+		JOIN songs ON songs.id = i.song_id
+		` + songStatsJoin + `
+		WHERE i.item_id = $2`
+
+	item, err := scanPlaylistItem(tx.QueryRowContext(ctx, query, playlistID, itemID), playlistID)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrPlaylistItemNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting playlist item: %w", err)
+	}
+	return item, nil
+}
+
+// playlistSongColumns are songColumns, with an empty text unless the lyrics
+// are wanted.
+func playlistSongColumns(withLyrics bool) string {
+	if withLyrics {
+		return songColumns
+	}
+	return strings.Replace(songColumns, "COALESCE(text, '')", "''", 1)
+}
+
+func scanPlaylistItem(row rowScanner, playlistID int64) (*entity.PlaylistItem, error) {
+	item := &entity.PlaylistItem{PlaylistID: playlistID}
+	song, err := scanSong(withPrefix(row, &item.ID, &item.Position, &item.AddedAt))
+	if err != nil {
+		return nil, err
+	}
+	item.SongID = song.ID
+	item.Song = song
+	return item, nil
+}
+
+func scanPlaylist(row rowScanner) (*entity.Playlist, error) {
+	playlist := &entity.Playlist{}
+	var visibility string
+	err := row.Scan(
+		&playlist.ID,
+		&playlist.UserID,
+		&playlist.OwnerUsername,
+		&playlist.Name,
+		&playlist.Description,
+		&visibility,
+		&playlist.ItemCount,
+		&playlist.CreatedAt,
+		&playlist.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	playlist.Visibility = entity.PlaylistVisibility(visibility)
+	return playlist, nil
+}
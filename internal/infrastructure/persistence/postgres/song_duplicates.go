@@ -0,0 +1,288 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+)
+
+// duplicateKey reduces a name to its letters and digits, without case or
+// accents, so that "AC/DC" and "acdc" or "Beyoncé" and "Beyonce" compare
+// equal.
+const duplicateKey = `regexp_replace(lower(immutable_unaccent(%s)), '[^[:alnum:]]+', '', 'g')`
+
+// songReferenceMerges move what references a duplicate ($2) over to the song
+// it is merged into ($1), skipping what the kept song already has.
+var songReferenceMerges = []string{
+	`UPDATE song_translations t SET song_id = $1
+	 WHERE t.song_id = $2
+	   AND NOT EXISTS (SELECT 1 FROM song_translations k WHERE k.song_id = $1 AND k.language = t.language)`,
+	`UPDATE song_synced_lines SET song_id = $1
+	 WHERE song_id = $2
+	   AND NOT EXISTS (SELECT 1 FROM song_synced_lines WHERE song_id = $1)`,
+	`UPDATE album_tracks t SET song_id = $1
+	 WHERE t.song_id = $2
+	   AND NOT EXISTS (SELECT 1 FROM album_tracks k WHERE k.album_id = t.album_id AND k.song_id = $1)`,
+	`UPDATE playlist_items SET song_id = $1 WHERE song_id = $2`,
+	`UPDATE song_favorites f SET song_id = $1
+	 WHERE f.song_id = $2
+	   AND NOT EXISTS (SELECT 1 FROM song_favorites k WHERE k.song_id = $1 AND k.user_id = f.user_id)`,
+	`UPDATE song_ratings r SET song_id = $1
+	 WHERE r.song_id = $2
+	   AND NOT EXISTS (SELECT 1 FROM song_ratings k WHERE k.song_id = $1 AND k.user_id = r.user_id)`,
+	`UPDATE song_plays SET song_id = $1 WHERE song_id = $2`,
+}
+
+// FindDuplicates lists groups of songs that are probably the same song. The
+// unique key on artist and normalised title already rules out exact
+// duplicates, so normalized mode compares a looser key; fuzzy mode pairs
+// songs by trigram similarity and ranks the closest pairs first.
+func (r *SongRepository) FindDuplicates(ctx context.Context, query *entity.DuplicateQuery) ([]*entity.DuplicateGroup, int, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	offset := (query.Page - 1) * query.PageSize
+
+	var rows *sql.Rows
+	switch query.Mode {
+	case entity.DuplicatesFuzzy:
+		// The % operator uses the trigram indexes and compares against this
+		// setting, which only lasts until the transaction ends.
+		threshold := strconv.FormatFloat(query.MinSimilarity, 'f', -1, 64)
+		if _, err := tx.ExecContext(ctx, `SELECT set_config('pg_trgm.similarity_threshold', $1, true)`, threshold); err != nil {
+			return nil, 0, fmt.Errorf("error setting similarity threshold: %w", err)
+		}
+		rows, err = tx.QueryContext(ctx, `
+			SELECT ARRAY[a.id, b.id]::bigint[],
+				((similarity(a.group_name, b.group_name) + similarity(a.song_name, b.song_name)) / 2)::float8 AS score,
+				COUNT(*) OVER()
+			FROM songs a
+			JOIN songs b ON b.song_name % a.song_name AND b.id > a.id
+			WHERE a.deleted_at IS NULL AND b.deleted_at IS NULL
+			  AND similarity(a.group_name, b.group_name) >= $1
+			  AND similarity(a.song_name, b.song_name) >= $1
+			ORDER BY score DESC, a.id, b.id
+			LIMIT $2 OFFSET $3`, query.MinSimilarity, query.PageSize, offset)
+	default:
+		rows, err = tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT ids, 1::float8, COUNT(*) OVER()
+			FROM (
+				SELECT array_agg(id ORDER BY id)::bigint[] AS ids
+				FROM songs
+				WHERE deleted_at IS NULL
+				GROUP BY %s, %s
+				HAVING COUNT(*) > 1
+			) duplicates
+			ORDER BY ids[1]
+			LIMIT $1 OFFSET $2`, fmt.Sprintf(duplicateKey, "group_name"), fmt.Sprintf(duplicateKey, "song_name")),
+			query.PageSize, offset)
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to find duplicate songs", zap.Error(err))
+		return nil, 0, fmt.Errorf("error finding duplicate songs: %w", err)
+	}
+
+	var groups []*entity.DuplicateGroup
+	var groupIDs [][]int64
+	var allIDs []int64
+	total := 0
+	for rows.Next() {
+		var ids []int64
+		group := &entity.DuplicateGroup{}
+		if err := rows.Scan(pq.Array(&ids), &group.Similarity, &total); err != nil {
+			rows.Close()
+			return nil, 0, fmt.Errorf("error scanning duplicate songs: %w", err)
+		}
+		groups = append(groups, group)
+		groupIDs = append(groupIDs, ids)
+		allIDs = append(allIDs, ids...)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error finding duplicate songs: %w", err)
+	}
+	if len(groups) == 0 {
+		return groups, total, nil
+	}
+
+	songRows, err := tx.QueryContext(ctx, `SELECT `+songColumns+` FROM songs `+songStatsJoin+` WHERE id = ANY($1)`, pq.Array(allIDs))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error loading duplicate songs: %w", err)
+	}
+	defer songRows.Close()
+
+	songs := make(map[int64]*entity.Song, len(allIDs))
+	for songRows.Next() {
+		song, err := scanSong(songRows)
+		if err != nil {
+			return nil, 0, err
+		}
+		songs[song.ID] = song
+	}
+	if err := songRows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error loading duplicate songs: %w", err)
+	}
+
+	for i, group := range groups {
+		for _, id := range groupIDs[i] {
+			if song, ok := songs[id]; ok {
+				group.Songs = append(group.Songs, song)
+			}
+		}
+	}
+
+	return groups, total, nil
+}
+
+// Merge folds the duplicates into the kept song and deletes them, all in one
+// transaction. The kept song's values are stored as a revision first; its
+// empty release date, text and link are then filled from the duplicates in
+// the given order, and the references of songReferenceMerges are moved over.
+// Revisions of the duplicates are deleted with them.
+func (r *SongRepository) Merge(ctx context.Context, keeperID int64, duplicateIDs []int64, info entity.RevisionInfo) (*entity.Song, error) {
+	r.logger.Debug(ctx, "Starting song merge in DB",
+		zap.Int64("id", keeperID),
+		zap.Int64s("duplicates", duplicateIDs))
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.saveRevision(ctx, tx, keeperID, info, 0); err != nil {
+		return nil, err
+	}
+
+	keeper, err := scanSong(tx.QueryRowContext(ctx, `SELECT `+songColumns+` FROM songs `+songStatsJoin+` WHERE id = $1`, keeperID))
+	if err != nil {
+		return nil, fmt.Errorf("error loading song: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+songColumns+`
+		FROM songs `+songStatsJoin+`
+		WHERE id = ANY($1::int[]) AND deleted_at IS NULL
+		ORDER BY array_position($1::int[], id)
+		FOR UPDATE OF songs`, pq.Array(duplicateIDs))
+	if err != nil {
+		r.logger.Error(ctx, "Failed to lock duplicate songs", zap.Error(err))
+		return nil, fmt.Errorf("error locking duplicate songs: %w", err)
+	}
+	var duplicates []*entity.Song
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		duplicates = append(duplicates, song)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error locking duplicate songs: %w", err)
+	}
+	if id, ok := missingDuplicate(duplicateIDs, duplicates); ok {
+		r.logger.Warn(ctx, "Duplicate song not found during merge", zap.Int64("id", id))
+		return nil, fmt.Errorf("%w: %d", repository.ErrSongNotFound, id)
+	}
+
+	textFrom := fillFromDuplicates(keeper, duplicates)
+
+	// The sections belong to the text, so they come along with it.
+	if textFrom != 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM song_sections WHERE song_id = $1`, keeperID); err != nil {
+			return nil, fmt.Errorf("error merging song sections: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE song_sections SET song_id = $1 WHERE song_id = $2`, keeperID, textFrom); err != nil {
+			return nil, fmt.Errorf("error merging song sections: %w", err)
+		}
+	}
+
+	for _, duplicate := range duplicates {
+		for _, query := range songReferenceMerges {
+			if _, err := tx.ExecContext(ctx, query, keeperID, duplicate.ID); err != nil {
+				r.logger.Error(ctx, "Failed to move song references", zap.Int64("duplicate", duplicate.ID), zap.Error(err))
+				return nil, fmt.Errorf("error moving song references: %w", err)
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM songs WHERE id = ANY($1::int[])`, pq.Array(duplicateIDs)); err != nil {
+		r.logger.Error(ctx, "Failed to delete merged songs", zap.Error(err))
+		return nil, fmt.Errorf("error deleting merged songs: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, songStatsRecount, keeperID); err != nil {
+		r.logger.Error(ctx, "Failed to recount merged song stats", zap.Error(err))
+		return nil, fmt.Errorf("error recounting song stats: %w", err)
+	}
+
+	merged, err := scanSong(tx.QueryRowContext(ctx, `
+		UPDATE songs
+		SET release_date = $2, text = $3, link = $4, updated_at = NOW(), version = version + 1
+		FROM song_stats st
+		WHERE id = $1 AND st.song_id = songs.id
+		RETURNING `+songColumns,
+		keeperID, nullTime(keeper.ReleaseDate), keeper.Text, keeper.Link))
+	if err != nil {
+		r.logger.Error(ctx, "Failed to update merged song", zap.Error(err))
+		return nil, fmt.Errorf("error updating merged song: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	r.logger.Info(ctx, "Songs successfully merged in DB",
+		zap.Int64("id", keeperID),
+		zap.Int("merged", len(duplicates)))
+	return merged, nil
+}
+
+// missingDuplicate returns the first requested duplicate that is not among
+// the live songs that were found.
+func missingDuplicate(ids []int64, found []*entity.Song) (int64, bool) {
+	live := make(map[int64]bool, len(found))
+	for _, song := range found {
+		live[song.ID] = true
+	}
+	for _, id := range ids {
+		if !live[id] {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// fillFromDuplicates fills the release date, text and link the kept song
+// lacks from the first duplicate that has them, in the given order. It
+// returns the duplicate the text came from, or 0 when the song kept its own.
+func fillFromDuplicates(keeper *entity.Song, duplicates []*entity.Song) int64 {
+	var textFrom int64
+	for _, duplicate := range duplicates {
+		if keeper.ReleaseDate.IsZero() {
+			keeper.ReleaseDate = duplicate.ReleaseDate
+		}
+		if keeper.Text == "" && duplicate.Text != "" {
+			keeper.Text = duplicate.Text
+			textFrom = duplicate.ID
+		}
+		if keeper.Link == "" {
+			keeper.Link = duplicate.Link
+		}
+	}
+	return textFrom
+}
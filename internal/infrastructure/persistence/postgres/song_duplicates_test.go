@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"song-library/internal/domain/entity"
+)
+
+func TestMissingDuplicate(t *testing.T) {
+	live := []*entity.Song{{ID: 2}, {ID: 3}}
+
+	tests := []struct {
+		name   string
+		ids    []int64
+		want   int64
+		wantOK bool
+	}{
+		{name: "all found", ids: []int64{2, 3}},
+		{name: "missing or trashed", ids: []int64{2, 4, 3}, want: 4, wantOK: true},
+		{name: "first missing one", ids: []int64{5, 4}, want: 5, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := missingDuplicate(tt.ids, live)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("missingDuplicate(%v) = %d, %v, want %d, %v", tt.ids, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFillFromDuplicates(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name         string
+		keeper       entity.Song
+		duplicates   []*entity.Song
+		want         entity.Song
+		wantTextFrom int64
+	}{
+		{
+			name:       "keeps its own fields",
+			keeper:     entity.Song{ID: 1, ReleaseDate: day(1), Text: "own", Link: "https://own"},
+			duplicates: []*entity.Song{{ID: 2, ReleaseDate: day(2), Text: "other", Link: "https://other"}},
+			want:       entity.Song{ID: 1, ReleaseDate: day(1), Text: "own", Link: "https://own"},
+		},
+		{
+			name:         "fills every empty field",
+			keeper:       entity.Song{ID: 1},
+			duplicates:   []*entity.Song{{ID: 2, ReleaseDate: day(2), Text: "other", Link: "https://other"}},
+			want:         entity.Song{ID: 1, ReleaseDate: day(2), Text: "other", Link: "https://other"},
+			wantTextFrom: 2,
+		},
+		{
+			name:   "the first duplicate with a value wins",
+			keeper: entity.Song{ID: 1},
+			duplicates: []*entity.Song{
+				{ID: 3, Link: "https://third"},
+				{ID: 2, ReleaseDate: day(2), Text: "second", Link: "https://second"},
+				{ID: 4, ReleaseDate: day(4), Text: "fourth"},
+			},
+			want:         entity.Song{ID: 1, ReleaseDate: day(2), Text: "second", Link: "https://third"},
+			wantTextFrom: 2,
+		},
+		{
+			name:       "fields are filled independently",
+			keeper:     entity.Song{ID: 1, Text: "own"},
+			duplicates: []*entity.Song{{ID: 2, ReleaseDate: day(2), Text: "other"}},
+			want:       entity.Song{ID: 1, ReleaseDate: day(2), Text: "own"},
+		},
+		{
+			name:       "nothing to fill from",
+			keeper:     entity.Song{ID: 1},
+			duplicates: []*entity.Song{{ID: 2}},
+			want:       entity.Song{ID: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keeper := tt.keeper
+			textFrom := fillFromDuplicates(&keeper, tt.duplicates)
+
+			if !keeper.ReleaseDate.Equal(tt.want.ReleaseDate) || keeper.Text != tt.want.Text || keeper.Link != tt.want.Link {
+				t.Errorf("merged song = %v %q %q, want %v %q %q",
+					keeper.ReleaseDate, keeper.Text, keeper.Link, tt.want.ReleaseDate, tt.want.Text, tt.want.Link)
+			}
+			if textFrom != tt.wantTextFrom {
+				t.Errorf("text taken from %d, want %d", textFrom, tt.wantTextFrom)
+			}
+		})
+	}
+}
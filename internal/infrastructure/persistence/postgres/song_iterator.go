@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+)
+
+// songIterator pages through songs by id (keyset pagination), running one
+// short query per batch.
+type songIterator struct {
+	repo      *SongRepository
+	filter    *entity.SongFilter
+	batchSize int
+
+	batch   []*entity.Song
+	current *entity.Song
+	afterID int64
+	done    bool
+	err     error
+}
+
+// Iterate returns an iterator over the songs matching filter; Page and
+// PageSize of the filter are ignored.
+func (r *SongRepository) Iterate(filter *entity.SongFilter, batchSize int) repository.SongIterator {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	return &songIterator{repo: r, filter: filter, batchSize: batchSize}
+}
+
+func (it *songIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.batch) == 0 {
+		if it.done {
+			return false
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return false
+		}
+		if len(it.batch) == 0 {
+			return false
+		}
+	}
+
+	it.current = it.batch[0]
+	it.batch = it.batch[1:]
+	return true
+}
+
+func (it *songIterator) Song() *entity.Song {
+	return it.current
+}
+
+func (it *songIterator) Err() error {
+	return it.err
+}
+
+func (it *songIterator) fetch(ctx context.Context) error {
+	conditions, args := songFilterConditions(it.filter)
+	conditions = append(conditions, fmt.Sprintf("id > $%d", len(args)+1))
+	args = append(args, it.afterID, it.batchSize)
+
+	query := `SELECT ` + songColumns + `
+		FROM songs ` + songStatsJoin + `
+		WHERE ` + strings.Join(conditions, " AND ") + fmt.Sprintf(`
+		ORDER BY id
+		LIMIT $%d`, len(args))
+
+	// Each batch runs in its own short transaction, which carries the
+	// similarity threshold of fuzzy name filters.
+	tx, err := it.repo.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := setSimilarityThreshold(ctx, tx, it.filter); err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	batch := make([]*entity.Song, 0, it.batchSize)
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			return fmt.Errorf("error scanning result: %w", err)
+		}
+		batch = append(batch, song)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating result: %w", err)
+	}
+
+	it.batch = batch
+	it.done = len(batch) < it.batchSize
+	if len(batch) > 0 {
+		it.afterID = batch[len(batch)-1].ID
+	}
+	return nil
+}
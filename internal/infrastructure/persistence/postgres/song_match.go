@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+)
+
+// MatchEntries looks each entry up by artist and normalised title, the
+// unique key of songs, and falls back to the oldest song with the same link.
+func (r *SongRepository) MatchEntries(ctx context.Context, entries []entity.PlaylistEntry) ([]*entity.Song, error) {
+	r.logger.Debug(ctx, "Starting playlist entry matching in DB", zap.Int("entries", len(entries)))
+
+	groups := make([]string, len(entries))
+	songs := make([]string, len(entries))
+	links := make([]string, len(entries))
+	for i, entry := range entries {
+		groups[i], songs[i], links[i] = entry.GroupName, entry.SongName, entry.Link
+	}
+
+	query := `
+		SELECT k.entry_n, ` + songColumns + `
+		FROM (
+			SELECT e.entry_n, COALESCE(
+				(SELECT s.id FROM songs s JOIN artists a ON a.id = s.artist_id
+				 WHERE s.deleted_at IS NULL
+				   AND a.normalized_name = lower(btrim(regexp_replace(e.entry_group, '\s+', ' ', 'g')))
+				   AND s.normalized_name = lower(btrim(regexp_replace(e.entry_song, '\s+', ' ', 'g')))
+				 LIMIT 1),
+				(SELECT s.id FROM songs s
+				 WHERE s.deleted_at IS NULL AND e.entry_link <> '' AND s.link = e.entry_link
+				 ORDER BY s.id
+				 LIMIT 1)
+			) AS match_id
+			FROM unnest($1::text[], $2::text[], $3::text[])
+				WITH ORDINALITY AS e(entry_group, entry_song, entry_link, entry_n)
+		) k
+		JOIN songs ON songs.id = k.match_id
+		` + songStatsJoin
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(groups), pq.Array(songs), pq.Array(links))
+	if err != nil {
+		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	matches := make([]*entity.Song, len(entries))
+	for rows.Next() {
+		var n int
+		song, err := scanSong(withPrefix(rows, &n))
+		if err != nil {
+			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			return nil, fmt.Errorf("error scanning result: %w", err)
+		}
+		matches[n-1] = song
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	return matches, nil
+}
@@ -3,9 +3,14 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"html"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 
 	"song-library/internal/domain/entity"
@@ -13,6 +18,34 @@ import (
 	"song-library/pkg/logger"
 )
 
+const songColumns = `id, artist_id, group_name, song_name, release_date, COALESCE(text, ''), COALESCE(link, ''),
+	enrichment_status, enrichment_attempts, COALESCE(enrichment_error, ''), created_at, updated_at, version,
+	deleted_at, st.favorite_count, st.rating_count, st.average_rating, st.play_count`
+
+// songStatsJoin brings in the song_stats row that songColumns read the
+// aggregates from. Every song gets one when it is created.
+const songStatsJoin = `JOIN song_stats st ON st.song_id = songs.id`
+
+const revisionColumns = `id, song_id, revision, group_name, song_name, release_date, COALESCE(text, ''),
+	COALESCE(link, ''), editor, COALESCE(restored_from, 0), created_at`
+
+// liveSongCondition limits rows of a table keyed by song_id to songs that are
+// not in the trash.
+const liveSongCondition = `EXISTS (SELECT 1 FROM songs live WHERE live.id = song_id AND live.deleted_at IS NULL)`
+
+// upsertArtistCTE resolves the artist named by $1, creating it on first use,
+// so that songs always reference a single row per normalised group name.
+const upsertArtistCTE = `
+		WITH artist AS (
+			INSERT INTO artists (name) VALUES (btrim(regexp_replace($1, '\s+', ' ', 'g')))
+			ON CONFLICT (normalized_name) DO UPDATE SET name = artists.name
+			RETURNING id, name
+		)`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type SongRepository struct {
 	db     *sql.DB
 	logger *logger.Logger
@@ -30,75 +63,262 @@ func (r *SongRepository) Create(ctx context.Context, song *entity.Song) error {
 		zap.String("group", song.GroupName),
 		zap.String("song", song.SongName))
 
-	query := `
-		INSERT INTO songs (group_name, song_name, release_date, text, link, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
-		RETURNING id, created_at, updated_at`
+	if song.EnrichmentStatus == "" {
+		song.EnrichmentStatus = entity.EnrichmentPending
+	}
+
+	query := upsertArtistCTE + `
+		INSERT INTO songs (artist_id, group_name, song_name, release_date, text, link, enrichment_status,
+			next_enrichment_at, created_at, updated_at)
+		VALUES ((SELECT id FROM artist), (SELECT name FROM artist), $2, $3, $4, $5, $6,
+			CASE WHEN $7 THEN NOW() END, NOW(), NOW())
+		RETURNING id, artist_id, group_name, created_at, updated_at, version`
 
-	err := r.db.QueryRowContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(
 		ctx, query,
 		song.GroupName,
 		song.SongName,
-		song.ReleaseDate,
+		nullTime(song.ReleaseDate),
 		song.Text,
 		song.Link,
-	).Scan(&song.ID, &song.CreatedAt, &song.UpdatedAt)
+		song.EnrichmentStatus,
+		song.EnrichmentStatus == entity.EnrichmentPending,
+	).Scan(&song.ID, &song.ArtistID, &song.GroupName, &song.CreatedAt, &song.UpdatedAt, &song.Version)
 
 	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			r.logger.Warn(ctx, "Song already exists",
+				zap.String("group", song.GroupName),
+				zap.String("song", song.SongName))
+			return repository.ErrSongAlreadyExists
+		}
 		r.logger.Error(ctx, "Failed to create song in DB", zap.Error(err))
 		return fmt.Errorf("failed to create record: %w", err)
 	}
 
+	if err := r.createStats(ctx, tx, song.ID); err != nil {
+		return err
+	}
+	if err := r.replaceSections(ctx, tx, song.ID, song.Sections); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
 	r.logger.Info(ctx, "Song successfully created in DB", 
 		zap.Int64("id", song.ID),
 		zap.Time("created_at", song.CreatedAt))
 	return nil
 }
 
-func (r *SongRepository) Update(ctx context.Context, song *entity.Song) error {
+// CreateBatch inserts songs in one transaction, giving every song its own
+// savepoint so that a failing row does not abort the rest. The returned slice
+// holds the outcome for the song at the same index: nil when it was created,
+// ErrSongAlreadyExists when a song with the same normalised group and title
+// is already stored. The error is only set when the batch itself failed.
+func (r *SongRepository) CreateBatch(ctx context.Context, songs []*entity.Song) ([]error, error) {
+	r.logger.Debug(ctx, "Starting song batch creation in DB", zap.Int("songs", len(songs)))
+
+	query := upsertArtistCTE + `
+		INSERT INTO songs (artist_id, group_name, song_name, release_date, text, link, enrichment_status,
+			next_enrichment_at, created_at, updated_at)
+		SELECT (SELECT id FROM artist), (SELECT name FROM artist), $2::text, $3, $4, $5, $6,
+			CASE WHEN $7 THEN NOW() END, NOW(), NOW()
+		ON CONFLICT (artist_id, normalized_name) WHERE deleted_at IS NULL DO NOTHING
+		RETURNING id, artist_id, group_name, created_at, updated_at, version`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	results := make([]error, len(songs))
+	for i, song := range songs {
+		if song.EnrichmentStatus == "" {
+			song.EnrichmentStatus = entity.EnrichmentPending
+		}
+
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT import_song`); err != nil {
+			return nil, fmt.Errorf("error creating savepoint: %w", err)
+		}
+
+		err := tx.QueryRowContext(
+			ctx, query,
+			song.GroupName,
+			song.SongName,
+			nullTime(song.ReleaseDate),
+			song.Text,
+			song.Link,
+			song.EnrichmentStatus,
+			song.EnrichmentStatus == entity.EnrichmentPending,
+		).Scan(&song.ID, &song.ArtistID, &song.GroupName, &song.CreatedAt, &song.UpdatedAt, &song.Version)
+		if err == sql.ErrNoRows {
+			err = repository.ErrSongAlreadyExists
+		} else if err == nil {
+			if err = r.createStats(ctx, tx, song.ID); err == nil {
+				err = r.replaceSections(ctx, tx, song.ID, song.Sections)
+			}
+		}
+
+		if err != nil {
+			if !errors.Is(err, repository.ErrSongAlreadyExists) {
+				r.logger.Warn(ctx, "Failed to create song in batch",
+					zap.String("group", song.GroupName),
+					zap.String("song", song.SongName),
+					zap.Error(err))
+			}
+			if _, rbErr := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT import_song`); rbErr != nil {
+				return nil, fmt.Errorf("error rolling back to savepoint: %w", rbErr)
+			}
+			song.ID = 0
+			results[i] = err
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT import_song`); err != nil {
+			return nil, fmt.Errorf("error releasing savepoint: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	r.logger.Info(ctx, "Song batch successfully created in DB", zap.Int("songs", len(songs)))
+	return results, nil
+}
+
+// Update stores the new values of the song and keeps the previous ones as a
+// revision in the same transaction.
+func (r *SongRepository) Update(ctx context.Context, song *entity.Song, info entity.RevisionInfo) error {
+	return r.update(ctx, song, info, 0)
+}
 
+// UpdateIfVersion is Update guarded by optimistic locking: it fails with
+// ErrSongVersionConflict unless the stored song still has the given version.
+func (r *SongRepository) UpdateIfVersion(ctx context.Context, song *entity.Song, info entity.RevisionInfo, version int) error {
+	return r.update(ctx, song, info, version)
+}
+
+func (r *SongRepository) update(ctx context.Context, song *entity.Song, info entity.RevisionInfo, version int) error {
 	r.logger.Debug(ctx, "Starting song update in DB",
-		zap.Int64("id", song.ID))
+		zap.Int64("id", song.ID),
+		zap.Int("expected_version", version))
 
-	query := `
+	query := upsertArtistCTE + `
 		UPDATE songs 
-		SET group_name = $1, song_name = $2, release_date = $3, text = $4, link = $5, updated_at = NOW()
-		WHERE id = $6
-		RETURNING updated_at`
+		SET artist_id = (SELECT id FROM artist), group_name = (SELECT name FROM artist),
+			song_name = $2, release_date = $3, text = $4, link = $5,
+			enrichment_status = 'done', enrichment_error = NULL, next_enrichment_at = NULL, updated_at = NOW(),
+			version = version + 1
+		WHERE id = $6 AND deleted_at IS NULL
+		RETURNING artist_id, group_name, enrichment_status, created_at, updated_at, version`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	result, err := r.db.ExecContext(
+	if err := r.saveRevision(ctx, tx, song.ID, info, version); err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(
 		ctx, query,
 		song.GroupName,
 		song.SongName,
-		song.ReleaseDate,
+		nullTime(song.ReleaseDate),
 		song.Text,
 		song.Link,
 		song.ID,
-	)
+	).Scan(&song.ArtistID, &song.GroupName, &song.EnrichmentStatus, &song.CreatedAt, &song.UpdatedAt, &song.Version)
+	if err == sql.ErrNoRows {
+		r.logger.Warn(ctx, "Song not found during update", zap.Int64("id", song.ID))
+		return repository.ErrSongNotFound
+	}
 	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			r.logger.Warn(ctx, "Song already exists", zap.Int64("id", song.ID))
+			return repository.ErrSongAlreadyExists
+		}
 		r.logger.Error(ctx, "Failed to update song in DB", zap.Error(err))
 		return fmt.Errorf("error updating record: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		r.logger.Error(ctx, "Failed to get affected rows", zap.Error(err))
-		return fmt.Errorf("error getting affected rows: %w", err)
+	if err := r.replaceSections(ctx, tx, song.ID, song.Sections); err != nil {
+		return err
 	}
 
-	if rowsAffected == 0 {
-		r.logger.Warn(ctx, "Song not found during update", zap.Int64("id", song.ID))
-		return repository.ErrSongNotFound
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
 	}
 
 	r.logger.Info(ctx, "Song successfully updated in DB", zap.Int64("id", song.ID))
 	return nil
 }
 
+// saveRevision locks the song row, checks the expected version when one is
+// given and copies the current values into song_revisions.
+func (r *SongRepository) saveRevision(ctx context.Context, tx *sql.Tx, id int64, info entity.RevisionInfo, version int) error {
+	var current int
+	err := tx.QueryRowContext(ctx, `SELECT version FROM songs WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id).Scan(&current)
+	if err == sql.ErrNoRows {
+		r.logger.Warn(ctx, "Song not found during update", zap.Int64("id", id))
+		return repository.ErrSongNotFound
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to lock song", zap.Error(err))
+		return fmt.Errorf("error locking song: %w", err)
+	}
+	if version != 0 && version != current {
+		r.logger.Warn(ctx, "Song version conflict",
+			zap.Int64("id", id),
+			zap.Int("expected_version", version),
+			zap.Int("current_version", current))
+		return repository.ErrSongVersionConflict
+	}
+
+	query := `
+		INSERT INTO song_revisions (song_id, revision, group_name, song_name, release_date, text, link,
+			editor, restored_from, created_at)
+		SELECT s.id, COALESCE((SELECT MAX(revision) FROM song_revisions WHERE song_id = s.id), 0) + 1,
+			s.group_name, s.song_name, s.release_date, s.text, s.link, $2, NULLIF($3, 0), NOW()
+		FROM songs s
+		WHERE s.id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, id, info.Editor, info.RestoredFrom); err != nil {
+		r.logger.Error(ctx, "Failed to store song revision", zap.Error(err))
+		return fmt.Errorf("error storing song revision: %w", err)
+	}
+	return nil
+}
+
 func (r *SongRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM songs WHERE id = $1`
+	return r.delete(ctx, id, 0)
+}
 
-	result, err := r.db.ExecContext(ctx, query, id)
+// DeleteIfVersion deletes the song only if it still has the given version.
+func (r *SongRepository) DeleteIfVersion(ctx context.Context, id int64, version int) error {
+	return r.delete(ctx, id, version)
+}
+
+// delete moves the song to the trash. It stays there with its lyrics,
+// revisions and references until Restore brings it back or PurgeDeleted
+// removes it for good.
+func (r *SongRepository) delete(ctx context.Context, id int64, version int) error {
+	query := `UPDATE songs SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL AND ($2 = 0 OR version = $2)`
+
+	result, err := r.db.ExecContext(ctx, query, id, version)
 	if err != nil {
 		return fmt.Errorf("error deleting song: %w", err)
 	}
@@ -109,7 +329,10 @@ func (r *SongRepository) Delete(ctx context.Context, id int64) error {
 	}
 
 	if rows == 0 {
-		return repository.ErrSongNotFound
+		if err := r.ensureExists(ctx, id); err != nil {
+			return err
+		}
+		return repository.ErrSongVersionConflict
 	}
 
 	return nil
@@ -117,22 +340,11 @@ func (r *SongRepository) Delete(ctx context.Context, id int64) error {
 
 func (r *SongRepository) GetByID(ctx context.Context, id int64) (*entity.Song, error) {
 	query := `
-		SELECT id, group_name, song_name, release_date, text, link, created_at, updated_at
-		FROM songs
-		WHERE id = $1`
-
-	song := &entity.Song{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&song.ID,
-		&song.GroupName,
-		&song.SongName,
-		&song.ReleaseDate,
-		&song.Text,
-		&song.Link,
-		&song.CreatedAt,
-		&song.UpdatedAt,
-	)
+		SELECT ` + songColumns + `
+		FROM songs ` + songStatsJoin + `
+		WHERE id = $1 AND deleted_at IS NULL`
 
+	song, err := scanSong(r.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, repository.ErrSongNotFound
 	}
@@ -143,25 +355,20 @@ func (r *SongRepository) GetByID(ctx context.Context, id int64) (*entity.Song, e
 	return song, nil
 }
 
-func (r *SongRepository) GetSongTextByVerses(ctx context.Context, id int64, page, pageSize int) (*entity.SongText, error) {
-	r.logger.Debug(ctx, "Starting to get song text by verses", 
-		zap.Int64("id", id),
-		zap.Int("page", page),
-		zap.Int("page_size", pageSize))
+func (r *SongRepository) GetLyrics(ctx context.Context, id int64) (*entity.SongLyrics, error) {
+	r.logger.Debug(ctx, "Starting to get song lyrics", zap.Int64("id", id))
 
 	query := `
-		SELECT id, group_name, song_name, text
+		SELECT id, group_name, song_name, COALESCE(text, '')
 		FROM songs
-		WHERE id = $1`
-
-	var song entity.SongText
-	var fullText string
+		WHERE id = $1 AND deleted_at IS NULL`
 
+	var lyrics entity.SongLyrics
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&song.ID,
-		&song.GroupName,
-		&song.SongName,
-		&fullText,
+		&lyrics.ID,
+		&lyrics.GroupName,
+		&lyrics.SongName,
+		&lyrics.Text,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -172,40 +379,404 @@ func (r *SongRepository) GetSongTextByVerses(ctx context.Context, id int64, page
 		return nil, fmt.Errorf("error getting song: %w", err)
 	}
 
-	verses := strings.Split(fullText, "\n\n")
-	song.TotalVerses = len(verses)
-	
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if end > len(verses) {
-		end = len(verses)
+	sections, err := r.loadSections(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if start < len(verses) {
-		song.Verses = verses[start:end]
+	lyrics.Sections = sections
+
+	return &lyrics, nil
+}
+
+// ListWithoutSections returns songs that have lyrics but no parsed sections
+// yet, ordered by id and starting after afterID.
+func (r *SongRepository) ListWithoutSections(ctx context.Context, afterID int64, limit int) ([]*entity.SongLyrics, error) {
+	query := `
+		SELECT s.id, s.group_name, s.song_name, s.text
+		FROM songs s
+		WHERE s.id > $1
+		  AND COALESCE(s.text, '') <> ''
+		  AND NOT EXISTS (SELECT 1 FROM song_sections ss WHERE ss.song_id = s.id)
+		ORDER BY s.id
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing songs without sections: %w", err)
 	}
+	defer rows.Close()
 
-	song.Page = page
-	song.PageSize = pageSize
+	var result []*entity.SongLyrics
+	for rows.Next() {
+		lyrics := &entity.SongLyrics{}
+		if err := rows.Scan(&lyrics.ID, &lyrics.GroupName, &lyrics.SongName, &lyrics.Text); err != nil {
+			return nil, fmt.Errorf("error scanning result: %w", err)
+		}
+		result = append(result, lyrics)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *SongRepository) SaveSections(ctx context.Context, id int64, sections []entity.SongSection) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.replaceSections(ctx, tx, id, sections); err != nil {
+		return err
+	}
 
-	return &song, nil
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *SongRepository) loadSections(ctx context.Context, id int64) ([]entity.SongSection, error) {
+	query := `
+		SELECT section_type, ordinal, lines, is_repeat
+		FROM song_sections
+		WHERE song_id = $1
+		ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to load song sections", zap.Error(err))
+		return nil, fmt.Errorf("error loading song sections: %w", err)
+	}
+	defer rows.Close()
+
+	var sections []entity.SongSection
+	for rows.Next() {
+		var section entity.SongSection
+		if err := rows.Scan(&section.Type, &section.Ordinal, pq.Array(&section.Lines), &section.Repeat); err != nil {
+			return nil, fmt.Errorf("error scanning song section: %w", err)
+		}
+		sections = append(sections, section)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating song sections: %w", err)
+	}
+
+	return sections, nil
+}
+
+// createStats gives a new song the song_stats row its aggregates are kept in.
+func (r *SongRepository) createStats(ctx context.Context, tx *sql.Tx, id int64) error {
+	if _, err := tx.ExecContext(ctx, `INSERT INTO song_stats (song_id) VALUES ($1)`, id); err != nil {
+		r.logger.Error(ctx, "Failed to create song stats", zap.Error(err))
+		return fmt.Errorf("error creating song stats: %w", err)
+	}
+	return nil
+}
+
+func (r *SongRepository) replaceSections(ctx context.Context, tx *sql.Tx, id int64, sections []entity.SongSection) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM song_sections WHERE song_id = $1`, id); err != nil {
+		r.logger.Error(ctx, "Failed to clear song sections", zap.Error(err))
+		return fmt.Errorf("error clearing song sections: %w", err)
+	}
+
+	query := `
+		INSERT INTO song_sections (song_id, position, section_type, ordinal, lines, is_repeat)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for i, section := range sections {
+		lines := section.Lines
+		if lines == nil {
+			lines = []string{}
+		}
+		_, err := tx.ExecContext(ctx, query, id, i+1, section.Type, section.Ordinal, pq.Array(lines), section.Repeat)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to store song section", zap.Error(err))
+			return fmt.Errorf("error storing song section: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *SongRepository) GetSyncedLyrics(ctx context.Context, id int64) ([]entity.SyncedLine, error) {
+	query := `
+		SELECT start_ms, text, word_starts_ms, words
+		FROM song_synced_lines
+		WHERE song_id = $1 AND ` + liveSongCondition + `
+		ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to load synced lyrics", zap.Error(err))
+		return nil, fmt.Errorf("error loading synced lyrics: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []entity.SyncedLine
+	for rows.Next() {
+		var line entity.SyncedLine
+		var startMs int64
+		var wordStarts []int64
+		var words []string
+
+		if err := rows.Scan(&startMs, &line.Text, pq.Array(&wordStarts), pq.Array(&words)); err != nil {
+			return nil, fmt.Errorf("error scanning synced line: %w", err)
+		}
+
+		line.Start = time.Duration(startMs) * time.Millisecond
+		for i, word := range words {
+			line.Words = append(line.Words, entity.SyncedWord{
+				Start: time.Duration(wordStarts[i]) * time.Millisecond,
+				Text:  word,
+			})
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating synced lines: %w", err)
+	}
+
+	if len(lines) == 0 {
+		if err := r.ensureExists(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, repository.ErrSyncedLyricsNotFound
+	}
+
+	return lines, nil
+}
+
+// SaveSyncedLyrics replaces the song's synced lyrics as a whole.
+func (r *SongRepository) SaveSyncedLyrics(ctx context.Context, id int64, lines []entity.SyncedLine) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE songs SET updated_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to lock song", zap.Error(err))
+		return fmt.Errorf("error locking song: %w", err)
+	}
+	if err := checkAffected(result); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM song_synced_lines WHERE song_id = $1`, id); err != nil {
+		r.logger.Error(ctx, "Failed to clear synced lyrics", zap.Error(err))
+		return fmt.Errorf("error clearing synced lyrics: %w", err)
+	}
+
+	query := `
+		INSERT INTO song_synced_lines (song_id, position, start_ms, text, word_starts_ms, words)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for i, line := range lines {
+		wordStarts := make([]int64, len(line.Words))
+		words := make([]string, len(line.Words))
+		for j, word := range line.Words {
+			wordStarts[j] = word.Start.Milliseconds()
+			words[j] = word.Text
+		}
+
+		_, err := tx.ExecContext(ctx, query, id, i+1, line.Start.Milliseconds(), line.Text,
+			pq.Array(wordStarts), pq.Array(words))
+		if err != nil {
+			r.logger.Error(ctx, "Failed to store synced line", zap.Error(err))
+			return fmt.Errorf("error storing synced line: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	r.logger.Info(ctx, "Synced lyrics successfully stored",
+		zap.Int64("id", id),
+		zap.Int("lines", len(lines)))
+	return nil
+}
+
+func (r *SongRepository) DeleteSyncedLyrics(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM song_synced_lines WHERE song_id = $1 AND `+liveSongCondition, id)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to delete synced lyrics", zap.Error(err))
+		return fmt.Errorf("error deleting synced lyrics: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+	if deleted == 0 {
+		if err := r.ensureExists(ctx, id); err != nil {
+			return err
+		}
+		return repository.ErrSyncedLyricsNotFound
+	}
+	return nil
+}
+
+func (r *SongRepository) ensureExists(ctx context.Context, id int64) error {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("error checking song: %w", err)
+	}
+	if !exists {
+		return repository.ErrSongNotFound
+	}
+	return nil
 }
 
 func (r *SongRepository) List(ctx context.Context, filter *entity.SongFilter) ([]*entity.Song, int, error) {
 	r.logger.Debug(ctx, "Starting song list retrieval", zap.Any("filter", filter))
 
-	var conditions []string
+	conditions, args := songFilterConditions(filter)
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " AND " + strings.Join(conditions, " AND ")
+	}
+	countQuery := `SELECT COUNT(*) FROM songs ` + songStatsJoin + ` WHERE 1=1` + where
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := setSimilarityThreshold(ctx, tx, filter); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if !filter.SkipTotal {
+		err := tx.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to count total records", zap.Error(err))
+			return nil, 0, fmt.Errorf("error counting total records: %w", err)
+		}
+	}
+
+	keys, args, err := songSortKeys(filter, args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	backward := filter.Cursor != nil && filter.Cursor.Backward
+	if filter.Cursor != nil {
+		if len(filter.Cursor.Keys) != len(keys) {
+			return nil, 0, fmt.Errorf("%w: cursor does not match the sort order", repository.ErrInvalidSort)
+		}
+		// Keyset pagination: continue after (or before) the cursor row
+		// instead of skipping rows with OFFSET.
+		var condition string
+		condition, args = keysetCondition(keys, filter.Cursor, args)
+		where += " AND " + condition
+	}
+
+	query := `SELECT ` + sortKeyArray(keys) + `, ` + songColumns + `
+			  FROM songs ` + songStatsJoin + ` WHERE 1=1` + where + `
+			  ORDER BY ` + orderBy(keys, backward)
+
+	limit := filter.PageSize
+	if filter.Lookahead {
+		limit++
+	}
+	if filter.Cursor != nil {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, limit)
+	} else {
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+		args = append(args, limit, (filter.Page-1)*filter.PageSize)
+	}
+
+	r.logger.Debug(ctx, "Executing query to DB", 
+		zap.String("query", query),
+		zap.Any("args", args))
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		// Cursor key values are cast to the sort column types in SQL, so a
+		// cursor edited by hand fails there rather than in decoding.
+		if filter.Cursor != nil && isPgDataException(err) {
+			return nil, 0, fmt.Errorf("%w: cursor does not match the sort order", repository.ErrInvalidSort)
+		}
+		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
+		return nil, 0, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var songs []*entity.Song
+	for rows.Next() {
+		var sortKey []string
+		song, err := scanSong(withPrefix(rows, pq.Array(&sortKey)))
+		if err != nil {
+			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			return nil, 0, fmt.Errorf("error scanning result: %w", err)
+		}
+		song.SortKey = sortKey
+		songs = append(songs, song)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	if backward {
+		for i, j := 0, len(songs)-1; i < j; i, j = i+1, j-1 {
+			songs[i], songs[j] = songs[j], songs[i]
+		}
+	}
+
+	r.logger.Info(ctx, "Song list successfully retrieved", 
+		zap.Int("total", total),
+		zap.Int("retrieved", len(songs)))
+	return songs, total, nil
+}
+
+// songFilterConditions turns the filter into SQL conditions whose
+// placeholders are numbered from $1 in the order of the returned arguments.
+// Fuzzy name conditions need setSimilarityThreshold in the same transaction.
+func songFilterConditions(filter *entity.SongFilter) ([]string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
 	var args []interface{}
 	argNum := 1
 
-	if filter.GroupName != "" {
-		conditions = append(conditions, fmt.Sprintf("group_name ILIKE $%d", argNum))
-		args = append(args, "%"+filter.GroupName+"%")
+	if filter.ArtistID != 0 {
+		conditions = append(conditions, fmt.Sprintf("artist_id = $%d", argNum))
+		args = append(args, filter.ArtistID)
 		argNum++
 	}
-	if filter.SongName != "" {
-		conditions = append(conditions, fmt.Sprintf("song_name ILIKE $%d", argNum))
-		args = append(args, "%"+filter.SongName+"%")
-		argNum++
+	for _, name := range []struct{ column, value string }{
+		{"group_name", filter.GroupName},
+		{"song_name", filter.SongName},
+	} {
+		if name.value == "" {
+			continue
+		}
+		switch filter.NameMatch {
+		case entity.MatchFuzzy:
+			conditions = append(conditions, fmt.Sprintf("%s %% $%d", name.column, argNum))
+			args = append(args, name.value)
+			argNum++
+		case entity.MatchExact:
+			conditions = append(conditions, fmt.Sprintf("lower(%s) = lower($%d)", name.column, argNum))
+			args = append(args, name.value)
+			argNum++
+		case entity.MatchPrefix:
+			conditions = append(conditions, fmt.Sprintf("%s ILIKE $%d", name.column, argNum))
+			args = append(args, escapeLike(name.value)+"%")
+			argNum++
+		default:
+			conditions = append(conditions, fmt.Sprintf("%s ILIKE $%d", name.column, argNum))
+			args = append(args, "%"+escapeLike(name.value)+"%")
+			argNum++
+		}
 	}
 	if !filter.ReleaseDate.IsZero() {
 		conditions = append(conditions, fmt.Sprintf("DATE(release_date) = DATE($%d)", argNum))
@@ -214,68 +785,591 @@ func (r *SongRepository) List(ctx context.Context, filter *entity.SongFilter) ([
 	}
 	if filter.Text != "" {
 		conditions = append(conditions, fmt.Sprintf("text ILIKE $%d", argNum))
-		args = append(args, "%"+filter.Text+"%")
+		args = append(args, "%"+escapeLike(filter.Text)+"%")
 		argNum++
 	}
 	if filter.Link != "" {
 		conditions = append(conditions, fmt.Sprintf("link ILIKE $%d", argNum))
-		args = append(args, "%"+filter.Link+"%")
+		args = append(args, "%"+escapeLike(filter.Link)+"%")
 		argNum++
 	}
 
-	query := `SELECT id, group_name, song_name, release_date, text, link, created_at, updated_at 
-			  FROM songs WHERE 1=1`
-	countQuery := `SELECT COUNT(*) FROM songs WHERE 1=1`
+	if !filter.ReleasedFrom.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("release_date >= $%d::date", argNum))
+		args = append(args, filter.ReleasedFrom.Format("2006-01-02"))
+		argNum++
+	}
+	if !filter.ReleasedBefore.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("release_date < $%d::date", argNum))
+		args = append(args, filter.ReleasedBefore.Format("2006-01-02"))
+		argNum++
+	}
+	for _, bound := range []struct {
+		condition string
+		value     time.Time
+	}{
+		{"created_at >= $%d", filter.CreatedFrom},
+		{"created_at <= $%d", filter.CreatedTo},
+		{"updated_at >= $%d", filter.UpdatedFrom},
+		{"updated_at <= $%d", filter.UpdatedTo},
+	} {
+		if !bound.value.IsZero() {
+			conditions = append(conditions, fmt.Sprintf(bound.condition, argNum))
+			args = append(args, bound.value)
+			argNum++
+		}
+	}
 
-	if len(conditions) > 0 {
-		condStr := strings.Join(conditions, " AND ")
-		query += " AND " + condStr
-		countQuery += " AND " + condStr
+	if filter.HasLyrics != nil {
+		conditions = append(conditions, presenceCondition("text", *filter.HasLyrics))
+	}
+	if filter.HasLink != nil {
+		conditions = append(conditions, presenceCondition("link", *filter.HasLink))
+	}
+	for _, bound := range []struct {
+		column string
+		value  interface{}
+		set    bool
+	}{
+		{"st.average_rating", filter.MinAverageRating, filter.MinAverageRating > 0},
+		{"st.rating_count", filter.MinRatingCount, filter.MinRatingCount > 0},
+		{"st.favorite_count", filter.MinFavoriteCount, filter.MinFavoriteCount > 0},
+		{"st.play_count", filter.MinPlayCount, filter.MinPlayCount > 0},
+	} {
+		if bound.set {
+			conditions = append(conditions, fmt.Sprintf("%s >= $%d", bound.column, argNum))
+			args = append(args, bound.value)
+			argNum++
+		}
 	}
 
-	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
-	if err != nil {
-		r.logger.Error(ctx, "Failed to count total records", zap.Error(err))
-		return nil, 0, fmt.Errorf("error counting total records: %w", err)
+	return conditions, args
+}
+
+// setSimilarityThreshold makes the % operator of fuzzy name filters compare
+// against filter.MinSimilarity. Unlike a similarity() comparison, % can use
+// the trigram indexes; the setting only lasts until tx ends.
+func setSimilarityThreshold(ctx context.Context, tx *sql.Tx, filter *entity.SongFilter) error {
+	if filter.NameMatch != entity.MatchFuzzy {
+		return nil
+	}
+	threshold := strconv.FormatFloat(filter.MinSimilarity, 'f', -1, 64)
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('pg_trgm.similarity_threshold', $1, true)`, threshold); err != nil {
+		return fmt.Errorf("error setting similarity threshold: %w", err)
 	}
+	return nil
+}
 
-	query += fmt.Sprintf(" ORDER BY id LIMIT $%d OFFSET $%d", argNum, argNum+1)
-	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+func presenceCondition(column string, present bool) string {
+	if present {
+		return fmt.Sprintf("COALESCE(%s, '') <> ''", column)
+	}
+	return fmt.Sprintf("COALESCE(%s, '') = ''", column)
+}
 
-	r.logger.Debug(ctx, "Executing query to DB", 
-		zap.String("query", query),
-		zap.Any("args", args))
+// escapeLike makes % and _ in user input match literally in LIKE patterns.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// SuggestNames looks up the known group and song names closest to the given
+// ones by trigram similarity. Names that already exist, ignoring case, get
+// no suggestion.
+func (r *SongRepository) SuggestNames(ctx context.Context, groupName, songName string) (*entity.NameSuggestion, error) {
+	suggestion := &entity.NameSuggestion{}
+
+	for _, lookup := range []struct {
+		value  string
+		query  string
+		target *string
+	}{
+		{groupName, `SELECT name FROM artists WHERE name % $1 ORDER BY similarity(name, $1) DESC, name LIMIT 1`, &suggestion.GroupName},
+		{songName, `SELECT song_name FROM songs WHERE song_name % $1 AND deleted_at IS NULL ORDER BY similarity(song_name, $1) DESC, song_name LIMIT 1`, &suggestion.SongName},
+	} {
+		if lookup.value == "" {
+			continue
+		}
+
+		var name string
+		err := r.db.QueryRowContext(ctx, lookup.query, lookup.value).Scan(&name)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			r.logger.Error(ctx, "Failed to look up name suggestion", zap.Error(err))
+			return nil, fmt.Errorf("error looking up name suggestion: %w", err)
+		}
+		if !strings.EqualFold(name, lookup.value) {
+			*lookup.target = name
+		}
+	}
+
+	return suggestion, nil
+}
+
+// CompleteNames returns distinct names starting with the prefix. Names are
+// compared as lower(immutable_unaccent(name)), the expression the suggest
+// indexes are built on. Those indexes use text_pattern_ops, which orders by
+// bytes rather than by the collation, so the names are ordered the same way
+// (USING ~<~): the index range scan then returns them already sorted and
+// stops once limit names are found, instead of sorting every name that
+// matches a short prefix.
+func (r *SongRepository) CompleteNames(ctx context.Context, query *entity.NameCompletionQuery) (*entity.NameCompletions, error) {
+	completions := &entity.NameCompletions{GroupNames: []string{}, SongNames: []string{}}
+	pattern := escapeLike(query.Prefix) + "%"
+
+	for _, lookup := range []struct {
+		enabled bool
+		table   string
+		column  string
+		visible string
+		target  *[]string
+	}{
+		{query.Groups, "artists", "name", "TRUE", &completions.GroupNames},
+		{query.Songs, "songs", "song_name", "deleted_at IS NULL", &completions.SongNames},
+	} {
+		if !lookup.enabled {
+			continue
+		}
+
+		key := fmt.Sprintf("lower(immutable_unaccent(%s))", lookup.column)
+		rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+			SELECT DISTINCT ON (%[1]s) %[2]s
+			FROM %[3]s
+			WHERE %[1]s LIKE lower(immutable_unaccent($1)) AND %[4]s
+			ORDER BY %[1]s USING ~<~, %[2]s
+			LIMIT $2`, key, lookup.column, lookup.table, lookup.visible), pattern, query.Limit)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to complete names", zap.String("table", lookup.table), zap.Error(err))
+			return nil, fmt.Errorf("error completing names: %w", err)
+		}
+
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("error scanning name completion: %w", err)
+			}
+			*lookup.target = append(*lookup.target, name)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error completing names: %w", err)
+		}
+	}
+
+	return completions, nil
+}
+
+// ts_headline copies the lyrics verbatim, so matches are marked with
+// control characters and the snippet is HTML-escaped before the markers
+// become <b> tags; markup in the lyrics never reaches a client as HTML.
+const (
+	headlineStart = "\x02"
+	headlineStop  = "\x03"
+)
+
+var (
+	headlineOptions  = `StartSel="` + headlineStart + `", StopSel="` + headlineStop + `"`
+	headlineReplacer = strings.NewReplacer(headlineStart, "<b>", headlineStop, "</b>")
+)
+
+func highlightSnippet(snippet string) string {
+	return headlineReplacer.Replace(html.EscapeString(snippet))
+}
+
+func (r *SongRepository) Search(ctx context.Context, query *entity.SongSearchQuery) ([]*entity.SongSearchResult, int, error) {
+	r.logger.Debug(ctx, "Starting full-text song search",
+		zap.String("query", query.Query),
+		zap.Int("page", query.Page),
+		zap.Int("page_size", query.PageSize))
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	tsQuery := buildTSQuery(query.Query)
+	if tsQuery == "" {
+		return nil, 0, repository.ErrInvalidSearchQuery
+	}
+
+	countQuery := `SELECT COUNT(*) FROM songs WHERE search_vector @@ to_tsquery('simple', $1) AND deleted_at IS NULL`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, tsQuery).Scan(&total); err != nil {
+		r.logger.Error(ctx, "Failed to count search results", zap.Error(err))
+		return nil, 0, fmt.Errorf("error counting search results: %w", err)
+	}
+
+	searchQuery := `
+		WITH q AS (
+			SELECT to_tsquery('simple', $1) AS query
+		), hits AS (
+			SELECT s.id, ts_rank(s.search_vector, q.query) AS rank
+			FROM songs s, q
+			WHERE s.search_vector @@ q.query AND s.deleted_at IS NULL
+			ORDER BY rank DESC, s.id
+			LIMIT $2 OFFSET $3
+		)
+		SELECT s.id, s.group_name, s.song_name, s.release_date, COALESCE(s.link, ''), h.rank,
+			COALESCE(
+				(SELECT ts_headline('simple', verse, q.query, $4::text || ', HighlightAll=true')
+				 FROM regexp_split_to_table(COALESCE(s.text, ''), '\r?\n[ \t]*\r?\n') AS verse
+				 WHERE to_tsvector('simple', verse) @@ q.query
+				 ORDER BY ts_rank(to_tsvector('simple', verse), q.query) DESC
+				 LIMIT 1),
+				ts_headline('simple', COALESCE(s.text, ''), q.query, $4::text || ', MaxWords=35, MinWords=15')
+			) AS snippet
+		FROM hits h
+		JOIN songs s ON s.id = h.id
+		CROSS JOIN q
+		ORDER BY h.rank DESC, s.id`
+
+	rows, err := r.db.QueryContext(ctx, searchQuery, tsQuery, query.PageSize, (query.Page-1)*query.PageSize, headlineOptions)
 	if err != nil {
-		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
-		return nil, 0, fmt.Errorf("error executing query: %w", err)
+		r.logger.Error(ctx, "Failed to execute search query", zap.Error(err))
+		return nil, 0, fmt.Errorf("error executing search query: %w", err)
 	}
 	defer rows.Close()
 
-	var songs []*entity.Song
+	var results []*entity.SongSearchResult
 	for rows.Next() {
-		song := &entity.Song{}
+		result := &entity.SongSearchResult{}
+		var releaseDate sql.NullTime
+
 		err := rows.Scan(
-			&song.ID,
-			&song.GroupName,
-			&song.SongName,
-			&song.ReleaseDate,
-			&song.Text,
-			&song.Link,
-			&song.CreatedAt,
-			&song.UpdatedAt,
+			&result.Song.ID,
+			&result.Song.GroupName,
+			&result.Song.SongName,
+			&releaseDate,
+			&result.Song.Link,
+			&result.Rank,
+			&result.Snippet,
 		)
 		if err != nil {
-			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			r.logger.Error(ctx, "Failed to scan search result", zap.Error(err))
 			return nil, 0, fmt.Errorf("error scanning result: %w", err)
 		}
-		songs = append(songs, song)
+		if releaseDate.Valid {
+			result.Song.ReleaseDate = releaseDate.Time
+		}
+		result.Snippet = highlightSnippet(result.Snippet)
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
 	}
 
-	r.logger.Info(ctx, "Song list successfully retrieved", 
+	r.logger.Info(ctx, "Song search successfully completed",
 		zap.Int("total", total),
-		zap.Int("retrieved", len(songs)))
-	return songs, total, nil
+		zap.Int("retrieved", len(results)))
+	return results, total, nil
+}
+
+func (r *SongRepository) ListRevisions(ctx context.Context, songID int64, page, pageSize int) ([]*entity.SongRevision, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM song_revisions WHERE song_id = $1 AND `+liveSongCondition, songID).Scan(&total)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to count song revisions", zap.Error(err))
+		return nil, 0, fmt.Errorf("error counting song revisions: %w", err)
+	}
+	if total == 0 {
+		if err := r.ensureExists(ctx, songID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	query := `SELECT ` + revisionColumns + `
+		FROM song_revisions
+		WHERE song_id = $1 AND ` + liveSongCondition + `
+		ORDER BY revision DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, songID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to list song revisions", zap.Error(err))
+		return nil, 0, fmt.Errorf("error listing song revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*entity.SongRevision
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error scanning result: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	return revisions, total, nil
+}
+
+func (r *SongRepository) GetRevision(ctx context.Context, songID int64, revision int) (*entity.SongRevision, error) {
+	query := `SELECT ` + revisionColumns + ` FROM song_revisions
+		WHERE song_id = $1 AND revision = $2 AND ` + liveSongCondition
+
+	result, err := scanRevision(r.db.QueryRowContext(ctx, query, songID, revision))
+	if err == sql.ErrNoRows {
+		if err := r.ensureExists(ctx, songID); err != nil {
+			return nil, err
+		}
+		return nil, repository.ErrRevisionNotFound
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to get song revision", zap.Error(err))
+		return nil, fmt.Errorf("error getting song revision: %w", err)
+	}
+	return result, nil
+}
+
+func (r *SongRepository) ClaimForEnrichment(ctx context.Context, limit int, lease time.Duration) ([]*entity.Song, error) {
+	query := `
+		UPDATE songs
+		SET next_enrichment_at = NOW() + make_interval(secs => $2)
+		FROM song_stats st
+		WHERE id IN (
+			SELECT id FROM songs
+			WHERE enrichment_status IN ('pending', 'failed')
+			  AND deleted_at IS NULL
+			  AND next_enrichment_at IS NOT NULL
+			  AND next_enrichment_at <= NOW()
+			ORDER BY next_enrichment_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		AND st.song_id = songs.id
+		RETURNING ` + songColumns
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		r.logger.Error(ctx, "Failed to claim songs for enrichment", zap.Error(err))
+		return nil, fmt.Errorf("error claiming songs for enrichment: %w", err)
+	}
+	defer rows.Close()
+
+	var songs []*entity.Song
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning result: %w", err)
+		}
+		songs = append(songs, song)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	return songs, nil
+}
+
+// enrichmentClaimed limits an enrichment result to the song as it was
+// claimed: still waiting for enrichment, not in the trash and not edited
+// since, which would bump its version.
+const enrichmentClaimed = `enrichment_status IN ('pending', 'failed') AND deleted_at IS NULL`
+
+func (r *SongRepository) CompleteEnrichment(ctx context.Context, id int64, version int, enrichment *entity.SongEnrichment) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE songs
+		SET release_date = $1, text = $2, link = $3,
+			enrichment_status = 'done', enrichment_attempts = enrichment_attempts + 1,
+			enrichment_error = NULL, next_enrichment_at = NULL, updated_at = NOW(),
+			version = version + 1
+		WHERE id = $4 AND version = $5 AND ` + enrichmentClaimed + `
+		RETURNING artist_id`
+
+	var artistID int64
+	err = tx.QueryRowContext(ctx, query,
+		nullTime(enrichment.ReleaseDate),
+		enrichment.Text,
+		enrichment.Link,
+		id,
+		version,
+	).Scan(&artistID)
+	if err == sql.ErrNoRows {
+		return repository.ErrEnrichmentNotClaimed
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to store song enrichment", zap.Error(err))
+		return fmt.Errorf("error storing song enrichment: %w", err)
+	}
+
+	if err := r.replaceSections(ctx, tx, id, enrichment.Sections); err != nil {
+		return err
+	}
+
+	if enrichment.Album != nil {
+		if err := r.attachEnrichedAlbum(ctx, tx, id, artistID, enrichment.Album); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// attachEnrichedAlbum finds or creates the artist's album reported by the
+// music info API and links the song to it. Data already stored on the album
+// wins over upstream values, and an occupied track position is left alone.
+func (r *SongRepository) attachEnrichedAlbum(ctx context.Context, tx *sql.Tx, songID, artistID int64, album *entity.AlbumEnrichment) error {
+	albumQuery := `
+		INSERT INTO albums (artist_id, title, album_type, release_date, cover_link, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NOW(), NOW())
+		ON CONFLICT (artist_id, normalized_title) DO UPDATE
+		SET release_date = COALESCE(albums.release_date, EXCLUDED.release_date),
+			cover_link = COALESCE(albums.cover_link, EXCLUDED.cover_link)
+		RETURNING id`
+
+	var albumID int64
+	err := tx.QueryRowContext(ctx, albumQuery,
+		artistID,
+		album.Title,
+		album.Type,
+		nullTime(album.ReleaseDate),
+		album.CoverLink,
+	).Scan(&albumID)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to store enriched album", zap.Error(err))
+		return fmt.Errorf("error storing enriched album: %w", err)
+	}
+
+	trackQuery := `
+		INSERT INTO album_tracks (album_id, song_id, disc_number, track_number)
+		VALUES ($1, $2, $3, COALESCE(NULLIF($4, 0),
+			(SELECT COALESCE(MAX(track_number), 0) + 1 FROM album_tracks WHERE album_id = $1 AND disc_number = $3)))
+		ON CONFLICT DO NOTHING`
+
+	_, err = tx.ExecContext(ctx, trackQuery, albumID, songID, album.DiscNumber, album.TrackNumber)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to store enriched album track", zap.Error(err))
+		return fmt.Errorf("error storing enriched album track: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SongRepository) FailEnrichment(ctx context.Context, id int64, version int, reason string, nextAttemptAt *time.Time) error {
+	query := `
+		UPDATE songs
+		SET enrichment_status = 'failed', enrichment_attempts = enrichment_attempts + 1,
+			enrichment_error = $1, next_enrichment_at = $2, updated_at = NOW(), version = version + 1
+		WHERE id = $3 AND version = $4 AND ` + enrichmentClaimed
+
+	result, err := r.db.ExecContext(ctx, query, reason, nextAttemptAt, id, version)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to record enrichment failure", zap.Error(err))
+		return fmt.Errorf("error recording enrichment failure: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+	if rows == 0 {
+		return repository.ErrEnrichmentNotClaimed
+	}
+	return nil
+}
+
+func scanSong(row rowScanner) (*entity.Song, error) {
+	song := &entity.Song{}
+	var releaseDate, deletedAt sql.NullTime
+
+	err := row.Scan(
+		&song.ID,
+		&song.ArtistID,
+		&song.GroupName,
+		&song.SongName,
+		&releaseDate,
+		&song.Text,
+		&song.Link,
+		&song.EnrichmentStatus,
+		&song.EnrichmentAttempts,
+		&song.EnrichmentError,
+		&song.CreatedAt,
+		&song.UpdatedAt,
+		&song.Version,
+		&deletedAt,
+		&song.FavoriteCount,
+		&song.RatingCount,
+		&song.AverageRating,
+		&song.PlayCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if releaseDate.Valid {
+		song.ReleaseDate = releaseDate.Time
+	}
+	if deletedAt.Valid {
+		song.DeletedAt = deletedAt.Time
+	}
+	return song, nil
+}
+
+func scanRevision(row rowScanner) (*entity.SongRevision, error) {
+	revision := &entity.SongRevision{}
+	var releaseDate sql.NullTime
+
+	err := row.Scan(
+		&revision.ID,
+		&revision.SongID,
+		&revision.Revision,
+		&revision.GroupName,
+		&revision.SongName,
+		&releaseDate,
+		&revision.Text,
+		&revision.Link,
+		&revision.Editor,
+		&revision.RestoredFrom,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if releaseDate.Valid {
+		revision.ReleaseDate = releaseDate.Time
+	}
+	return revision, nil
+}
+
+func checkAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+	if rows == 0 {
+		return repository.ErrSongNotFound
+	}
+	return nil
+}
+
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+type prefixScanner struct {
+	row    rowScanner
+	prefix []interface{}
+}
+
+func (s prefixScanner) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(s.prefix, dest...)...)
+}
+
+// withPrefix lets scanSong read a row that starts with extra columns.
+func withPrefix(row rowScanner, prefix ...interface{}) rowScanner {
+	return prefixScanner{row: row, prefix: prefix}
 }
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+)
+
+// sortColumn is the SQL expression a sort field orders by and the type its
+// text value has to be cast back to when it is compared with a cursor.
+type sortColumn struct {
+	expr string
+	cast string
+}
+
+// songSortColumns is the whitelist of sortable fields; sort input from users
+// only ever selects one of these expressions. Songs without a release date
+// sort as the oldest.
+var songSortColumns = map[string]sortColumn{
+	entity.SortGroupName:     {expr: "group_name", cast: "text"},
+	entity.SortSongName:      {expr: "song_name", cast: "text"},
+	entity.SortReleaseDate:   {expr: "COALESCE(release_date, '-infinity'::date)", cast: "date"},
+	entity.SortCreatedAt:     {expr: "created_at", cast: "timestamptz"},
+	entity.SortUpdatedAt:     {expr: "updated_at", cast: "timestamptz"},
+	entity.SortAverageRating: {expr: "st.average_rating", cast: "float8"},
+	entity.SortRatingCount:   {expr: "st.rating_count", cast: "integer"},
+	entity.SortFavoriteCount: {expr: "st.favorite_count", cast: "integer"},
+	entity.SortPlayCount:     {expr: "st.play_count", cast: "bigint"},
+}
+
+type sortKey struct {
+	sortColumn
+	desc bool
+}
+
+// songSortKeys resolves filter.Sort against the whitelist. Relevance ranks
+// songs against filter.Text and similarity against the fuzzy name filters;
+// the values they compare with are added to args.
+func songSortKeys(filter *entity.SongFilter, args []interface{}) ([]sortKey, []interface{}, error) {
+	keys := make([]sortKey, 0, len(filter.Sort))
+	for _, sort := range filter.Sort {
+		if sort.Field == entity.SortRelevance {
+			tsQuery := buildTSQuery(filter.Text)
+			if tsQuery == "" {
+				return nil, nil, fmt.Errorf("%w: relevance needs a text query", repository.ErrInvalidSort)
+			}
+			args = append(args, tsQuery)
+			keys = append(keys, sortKey{
+				sortColumn: sortColumn{
+					expr: fmt.Sprintf("ts_rank(search_vector, to_tsquery('simple', $%d))", len(args)),
+					cast: "real",
+				},
+				desc: sort.Desc,
+			})
+			continue
+		}
+
+		if sort.Field == entity.SortSimilarity {
+			var terms []string
+			for _, name := range []struct{ column, value string }{
+				{"group_name", filter.GroupName},
+				{"song_name", filter.SongName},
+			} {
+				if name.value != "" {
+					args = append(args, name.value)
+					terms = append(terms, fmt.Sprintf("similarity(%s, $%d)", name.column, len(args)))
+				}
+			}
+			if filter.NameMatch != entity.MatchFuzzy || len(terms) == 0 {
+				return nil, nil, fmt.Errorf("%w: similarity needs a fuzzy group_name or song_name filter", repository.ErrInvalidSort)
+			}
+			keys = append(keys, sortKey{
+				sortColumn: sortColumn{expr: "(" + strings.Join(terms, " + ") + ")", cast: "real"},
+				desc:       sort.Desc,
+			})
+			continue
+		}
+
+		column, ok := songSortColumns[sort.Field]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: unknown field %q", repository.ErrInvalidSort, sort.Field)
+		}
+		keys = append(keys, sortKey{sortColumn: column, desc: sort.Desc})
+	}
+	return keys, args, nil
+}
+
+// orderBy orders by the sort keys and then by id. Walking backwards from a
+// cursor reverses every direction; the caller restores the order afterwards.
+func orderBy(keys []sortKey, backward bool) string {
+	parts := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		parts = append(parts, key.expr+direction(key.desc != backward))
+	}
+	parts = append(parts, "id"+direction(backward))
+	return strings.Join(parts, ", ")
+}
+
+func direction(desc bool) string {
+	if desc {
+		return " DESC"
+	}
+	return ""
+}
+
+// sortKeyArray selects the sort key values of every row as text so that a
+// cursor can be built from them.
+func sortKeyArray(keys []sortKey) string {
+	if len(keys) == 0 {
+		return "ARRAY[]::text[]"
+	}
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = key.expr + "::text"
+	}
+	return "ARRAY[" + strings.Join(values, ", ") + "]"
+}
+
+// keysetCondition matches the rows that come after the cursor row in the
+// given order (before it when walking backwards):
+// k1 > v1 OR (k1 = v1 AND k2 > v2) OR ... OR (k1 = v1 AND ... AND id > id0).
+func keysetCondition(keys []sortKey, cursor *entity.SongCursor, args []interface{}) (string, []interface{}) {
+	var alternatives []string
+	var equal []string
+
+	for i, key := range keys {
+		args = append(args, cursor.Keys[i])
+		value := fmt.Sprintf("$%d::%s", len(args), key.cast)
+
+		op := ">"
+		if key.desc != cursor.Backward {
+			op = "<"
+		}
+		alternatives = append(alternatives, "("+strings.Join(append(equal, key.expr+" "+op+" "+value), " AND ")+")")
+		equal = append(equal, key.expr+" = "+value)
+	}
+
+	args = append(args, cursor.ID)
+	op := ">"
+	if cursor.Backward {
+		op = "<"
+	}
+	alternatives = append(alternatives, "("+strings.Join(append(equal, fmt.Sprintf("id %s $%d", op, len(args))), " AND ")+")")
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
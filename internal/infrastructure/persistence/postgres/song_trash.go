@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+)
+
+// ListDeleted pages through the trash, most recently deleted first.
+func (r *SongRepository) ListDeleted(ctx context.Context, page, pageSize int) ([]*entity.Song, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM songs WHERE deleted_at IS NOT NULL`).Scan(&total); err != nil {
+		r.logger.Error(ctx, "Failed to count deleted songs", zap.Error(err))
+		return nil, 0, fmt.Errorf("error counting deleted songs: %w", err)
+	}
+
+	query := `
+		SELECT ` + songColumns + `
+		FROM songs ` + songStatsJoin + `
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.QueryContext(ctx, query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to list deleted songs", zap.Error(err))
+		return nil, 0, fmt.Errorf("error listing deleted songs: %w", err)
+	}
+	defer rows.Close()
+
+	var songs []*entity.Song
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error scanning result: %w", err)
+		}
+		songs = append(songs, song)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	return songs, total, nil
+}
+
+// Restore takes a song out of the trash. It fails with ErrSongNotFound when
+// the song is not in the trash and with ErrSongAlreadyExists when a song with
+// the same title has been created since.
+func (r *SongRepository) Restore(ctx context.Context, id int64) (*entity.Song, error) {
+	query := `
+		UPDATE songs
+		SET deleted_at = NULL
+		FROM song_stats st
+		WHERE id = $1 AND deleted_at IS NOT NULL AND st.song_id = songs.id
+		RETURNING ` + songColumns
+
+	song, err := scanSong(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		r.logger.Warn(ctx, "Song not found in trash", zap.Int64("id", id))
+		return nil, repository.ErrSongNotFound
+	}
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return nil, repository.ErrSongAlreadyExists
+		}
+		r.logger.Error(ctx, "Failed to restore song", zap.Error(err))
+		return nil, fmt.Errorf("error restoring song: %w", err)
+	}
+
+	r.logger.Info(ctx, "Song successfully restored from trash", zap.Int64("id", id))
+	return song, nil
+}
+
+// PurgeDeleted permanently deletes up to limit songs that were moved to the
+// trash before the given time, oldest first, and returns how many it deleted.
+func (r *SongRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM songs
+		WHERE id IN (
+			SELECT id FROM songs
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.db.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to purge deleted songs", zap.Error(err))
+		return 0, fmt.Errorf("error purging deleted songs: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error getting affected rows: %w", err)
+	}
+	return int(rows), nil
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+const translationColumns = `song_id, language, text, created_at, updated_at`
+
+type TranslationRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+func NewTranslationRepository(db *sql.DB, logger *logger.Logger) *TranslationRepository {
+	return &TranslationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TranslationRepository) Create(ctx context.Context, translation *entity.SongTranslation) error {
+	r.logger.Debug(ctx, "Starting translation creation in DB",
+		zap.Int64("song_id", translation.SongID),
+		zap.String("language", translation.Language))
+
+	query := `
+		INSERT INTO song_translations (song_id, language, text, created_at, updated_at)
+		SELECT id, $2, $3, NOW(), NOW() FROM songs WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + translationColumns
+
+	created, err := scanTranslation(r.db.QueryRowContext(ctx, query,
+		translation.SongID,
+		translation.Language,
+		translation.Text,
+	))
+	if err == sql.ErrNoRows {
+		return repository.ErrSongNotFound
+	}
+	if err != nil {
+		switch {
+		case isPgError(err, pgUniqueViolation):
+			return repository.ErrTranslationAlreadyExists
+		case isPgError(err, pgForeignKeyViolation):
+			return repository.ErrSongNotFound
+		}
+		r.logger.Error(ctx, "Failed to create translation in DB", zap.Error(err))
+		return fmt.Errorf("error creating translation: %w", err)
+	}
+
+	*translation = *created
+	r.logger.Info(ctx, "Translation successfully created in DB",
+		zap.Int64("song_id", translation.SongID),
+		zap.String("language", translation.Language))
+	return nil
+}
+
+func (r *TranslationRepository) Update(ctx context.Context, translation *entity.SongTranslation) error {
+	query := `
+		UPDATE song_translations
+		SET text = $1, updated_at = NOW()
+		WHERE song_id = $2 AND language = $3 AND ` + liveSongCondition + `
+		RETURNING ` + translationColumns
+
+	updated, err := scanTranslation(r.db.QueryRowContext(ctx, query,
+		translation.Text,
+		translation.SongID,
+		translation.Language,
+	))
+	if err == sql.ErrNoRows {
+		return r.notFound(ctx, translation.SongID)
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to update translation in DB", zap.Error(err))
+		return fmt.Errorf("error updating translation: %w", err)
+	}
+
+	*translation = *updated
+	return nil
+}
+
+func (r *TranslationRepository) Delete(ctx context.Context, songID int64, language string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM song_translations WHERE song_id = $1 AND language = $2 AND `+liveSongCondition, songID, language)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to delete translation from DB", zap.Error(err))
+		return fmt.Errorf("error deleting translation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	}
+	if rows == 0 {
+		return r.notFound(ctx, songID)
+	}
+	return nil
+}
+
+func (r *TranslationRepository) Get(ctx context.Context, songID int64, language string) (*entity.SongTranslation, error) {
+	query := `SELECT ` + translationColumns + ` FROM song_translations
+		WHERE song_id = $1 AND language = $2 AND ` + liveSongCondition
+
+	translation, err := scanTranslation(r.db.QueryRowContext(ctx, query, songID, language))
+	if err == sql.ErrNoRows {
+		return nil, r.notFound(ctx, songID)
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to get translation", zap.Error(err))
+		return nil, fmt.Errorf("error getting translation: %w", err)
+	}
+	return translation, nil
+}
+
+func (r *TranslationRepository) List(ctx context.Context, songID int64) ([]*entity.SongTranslation, error) {
+	query := `SELECT ` + translationColumns + ` FROM song_translations
+		WHERE song_id = $1 AND ` + liveSongCondition + `
+		ORDER BY language`
+
+	rows, err := r.db.QueryContext(ctx, query, songID)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to list translations", zap.Error(err))
+		return nil, fmt.Errorf("error listing translations: %w", err)
+	}
+	defer rows.Close()
+
+	var translations []*entity.SongTranslation
+	for rows.Next() {
+		translation, err := scanTranslation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning result: %w", err)
+		}
+		translations = append(translations, translation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	if len(translations) == 0 {
+		if err := r.songExists(ctx, songID); err != nil {
+			return nil, err
+		}
+	}
+
+	return translations, nil
+}
+
+// notFound tells a missing song apart from a song without the translation.
+func (r *TranslationRepository) notFound(ctx context.Context, songID int64) error {
+	if err := r.songExists(ctx, songID); err != nil {
+		return err
+	}
+	return repository.ErrTranslationNotFound
+}
+
+func (r *TranslationRepository) songExists(ctx context.Context, songID int64) error {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1 AND deleted_at IS NULL)`, songID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("error checking song: %w", err)
+	}
+	if !exists {
+		return repository.ErrSongNotFound
+	}
+	return nil
+}
+
+func scanTranslation(row rowScanner) (*entity.SongTranslation, error) {
+	translation := &entity.SongTranslation{}
+	err := row.Scan(
+		&translation.SongID,
+		&translation.Language,
+		&translation.Text,
+		&translation.CreatedAt,
+		&translation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"strings"
+	"unicode"
+)
+
+// buildTSQuery turns a user search string into to_tsquery syntax.
+// Quoted fragments become phrase queries, a trailing * marks a prefix
+// term and all remaining terms must match. Anything that is not a letter or
+// a digit is dropped, so user input can never inject tsquery operators.
+func buildTSQuery(input string) string {
+	var terms []string
+
+	for i, part := range strings.Split(input, `"`) {
+		if i%2 == 1 {
+			if term := phraseTerm(strings.Fields(part)); term != "" {
+				terms = append(terms, term)
+			}
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			if term := phraseTerm([]string{word}); term != "" {
+				terms = append(terms, term)
+			}
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+func phraseTerm(words []string) string {
+	var lexemes []string
+
+	for i, word := range words {
+		prefix := strings.HasSuffix(word, "*")
+		parts := strings.FieldsFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(parts) == 0 {
+			continue
+		}
+		if prefix && i == len(words)-1 {
+			parts[len(parts)-1] += ":*"
+		}
+		lexemes = append(lexemes, parts...)
+	}
+
+	if len(lexemes) == 0 {
+		return ""
+	}
+	if len(lexemes) == 1 {
+		return lexemes[0]
+	}
+	return "(" + strings.Join(lexemes, " <-> ") + ")"
+}
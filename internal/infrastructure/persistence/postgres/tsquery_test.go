@@ -0,0 +1,34 @@
+package postgres
+
+import "testing"
+
+func TestBuildTSQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "single word", input: "love", want: "love"},
+		{name: "all words must match", input: "love  me do", want: "love & me & do"},
+		{name: "prefix", input: "lov*", want: "lov:*"},
+		{name: "phrase", input: `"let it be"`, want: "(let <-> it <-> be)"},
+		{name: "phrase with prefix on the last word", input: `"let it b*"`, want: "(let <-> it <-> b:*)"},
+		{name: "phrase and words", input: `yellow "let it be" submarine`, want: "yellow & (let <-> it <-> be) & submarine"},
+		{name: "one-word phrase", input: `"help"`, want: "help"},
+		{name: "unterminated quote", input: `"hey jude`, want: "(hey <-> jude)"},
+		{name: "punctuation splits a word into a phrase", input: "AC/DC", want: "(AC <-> DC)"},
+		{name: "tsquery operators are dropped", input: "a & !b | (c) <-> d:*", want: "a & b & c & d:*"},
+		{name: "quotes are not injected", input: `it's`, want: "(it <-> s)"},
+		{name: "unicode letters and digits", input: "Любовь 99", want: "Любовь & 99"},
+		{name: "only punctuation", input: `!!! "" &`, want: ""},
+		{name: "empty", input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildTSQuery(tt.input); got != tt.want {
+				t.Errorf("buildTSQuery(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+const userColumns = `id, username, password_hash, role, disabled_at, created_at, updated_at`
+
+type UserRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+func NewUserRepository(db *sql.DB, logger *logger.Logger) *UserRepository {
+	return &UserRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
+	r.logger.Debug(ctx, "Starting user creation in DB", zap.String("username", user.Username))
+
+	query := `
+		INSERT INTO users (username, password_hash, role, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query, user.Username, user.PasswordHash, string(user.Role)).
+		Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return repository.ErrUserAlreadyExists
+		}
+		r.logger.Error(ctx, "Failed to create user in DB", zap.Error(err))
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	r.logger.Info(ctx, "User successfully created in DB", zap.Int64("id", user.ID))
+	return nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id int64) (*entity.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetByUsername ignores case, like the unique index on usernames.
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE lower(username) = lower($1)`
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, username))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) List(ctx context.Context, filter *entity.UserFilter) ([]*entity.User, int, error) {
+	r.logger.Debug(ctx, "Starting user list retrieval", zap.Any("filter", filter))
+
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if filter.Username != "" {
+		conditions = append(conditions, fmt.Sprintf("username ILIKE $%d", argNum))
+		args = append(args, "%"+filter.Username+"%")
+		argNum++
+	}
+	if filter.Role != "" {
+		conditions = append(conditions, fmt.Sprintf("role = $%d", argNum))
+		args = append(args, string(filter.Role))
+		argNum++
+	}
+	if filter.Disabled != nil {
+		if *filter.Disabled {
+			conditions = append(conditions, "disabled_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "disabled_at IS NULL")
+		}
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE 1=1`
+	countQuery := `SELECT COUNT(*) FROM users WHERE 1=1`
+
+	if len(conditions) > 0 {
+		condStr := strings.Join(conditions, " AND ")
+		query += " AND " + condStr
+		countQuery += " AND " + condStr
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		r.logger.Error(ctx, "Failed to count users", zap.Error(err))
+		return nil, 0, fmt.Errorf("error counting total records: %w", err)
+	}
+
+	query += fmt.Sprintf(" ORDER BY lower(username), id LIMIT $%d OFFSET $%d", argNum, argNum+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to execute query", zap.Error(err))
+		return nil, 0, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Failed to scan result", zap.Error(err))
+			return nil, 0, fmt.Errorf("error scanning result: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating result: %w", err)
+	}
+
+	r.logger.Info(ctx, "User list successfully retrieved",
+		zap.Int("total", total),
+		zap.Int("retrieved", len(users)))
+	return users, total, nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id int64, role entity.Role) (*entity.User, error) {
+	query := `UPDATE users SET role = $1, updated_at = NOW() WHERE id = $2 RETURNING ` + userColumns
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, string(role), id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrUserNotFound
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to update user role in DB", zap.Error(err))
+		return nil, fmt.Errorf("error updating user role: %w", err)
+	}
+
+	r.logger.Info(ctx, "User role successfully updated in DB",
+		zap.Int64("id", id),
+		zap.String("role", string(role)))
+	return user, nil
+}
+
+func (r *UserRepository) SetDisabled(ctx context.Context, id int64, disabled bool) (*entity.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Disabling an already disabled user keeps the original time.
+	query := `
+		UPDATE users
+		SET disabled_at = CASE WHEN $1 THEN COALESCE(disabled_at, NOW()) END, updated_at = NOW()
+		WHERE id = $2
+		RETURNING ` + userColumns
+
+	user, err := scanUser(tx.QueryRowContext(ctx, query, disabled, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrUserNotFound
+	}
+	if err != nil {
+		r.logger.Error(ctx, "Failed to update user in DB", zap.Error(err))
+		return nil, fmt.Errorf("error updating user: %w", err)
+	}
+
+	if disabled {
+		_, err = tx.ExecContext(ctx,
+			`UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, id)
+		if err != nil {
+			return nil, fmt.Errorf("error revoking refresh tokens: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	r.logger.Info(ctx, "User successfully updated in DB",
+		zap.Int64("id", id),
+		zap.Bool("disabled", disabled))
+	return user, nil
+}
+
+// CreateRefreshToken stores a token of a new family and drops the user's
+// expired tokens, which are of no use any more.
+func (r *UserRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM refresh_tokens WHERE user_id = $1 AND expires_at < NOW()`, token.UserID)
+	if err != nil {
+		return fmt.Errorf("error deleting expired refresh tokens: %w", err)
+	}
+
+	if err := insertRefreshToken(ctx, tx, token); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) RotateRefreshToken(ctx context.Context, hash []byte, next *entity.RefreshToken) (*entity.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current entity.RefreshToken
+	var revoked, expired bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, user_id, family, revoked_at IS NOT NULL, expires_at < NOW()
+		FROM refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE`, hash).
+		Scan(&current.ID, &current.UserID, &current.Family, &revoked, &expired)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting refresh token: %w", err)
+	}
+
+	if revoked {
+		if err := revokeFamily(ctx, tx, current.Family); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("error committing transaction: %w", err)
+		}
+		r.logger.Warn(ctx, "Revoked refresh token reused, token family revoked",
+			zap.Int64("user_id", current.UserID))
+		return nil, repository.ErrRefreshTokenReused
+	}
+	if expired {
+		return nil, repository.ErrRefreshTokenInvalid
+	}
+
+	user, err := scanUser(tx.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, current.UserID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user.Disabled() {
+		return nil, repository.ErrUserDisabled
+	}
+
+	next.UserID = current.UserID
+	next.Family = current.Family
+	if err := insertRefreshToken(ctx, tx, next); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = NOW(), replaced_by = $1 WHERE id = $2`,
+		next.ID, current.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error revoking refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+	return user, nil
+}
+
+func (r *UserRepository) RevokeRefreshToken(ctx context.Context, hash []byte) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE family = (SELECT family FROM refresh_tokens WHERE token_hash = $1)
+			AND revoked_at IS NULL`, hash)
+	if err != nil {
+		return fmt.Errorf("error revoking refresh token: %w", err)
+	}
+	return nil
+}
+
+func insertRefreshToken(ctx context.Context, tx *sql.Tx, token *entity.RefreshToken) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO refresh_tokens (user_id, family, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id`,
+		token.UserID, token.Family, token.Hash, token.ExpiresAt).Scan(&token.ID)
+	if err != nil {
+		return fmt.Errorf("error storing refresh token: %w", err)
+	}
+	return nil
+}
+
+func revokeFamily(ctx context.Context, tx *sql.Tx, family string) error {
+	_, err := tx.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at = NOW() WHERE family = $1 AND revoked_at IS NULL`, family)
+	if err != nil {
+		return fmt.Errorf("error revoking refresh token family: %w", err)
+	}
+	return nil
+}
+
+func scanUser(row rowScanner) (*entity.User, error) {
+	user := &entity.User{}
+	var role string
+	var disabledAt sql.NullTime
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.PasswordHash,
+		&role,
+		&disabledAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	user.Role = entity.Role(role)
+	user.DisabledAt = disabledAt.Time
+	return user, nil
+}
@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+type AlbumHandler struct {
+	useCase usecase.AlbumUseCase
+	logger  *logger.Logger
+}
+
+func NewAlbumHandler(useCase usecase.AlbumUseCase, logger *logger.Logger) *AlbumHandler {
+	return &AlbumHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Create godoc
+// @Summary Create an album
+// @Description Creates a new album (LP, EP or single) of an artist
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param request body dto.CreateAlbumRequest true "Album data"
+// @Success 201 {object} dto.AlbumResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/albums [post]
+func (h *AlbumHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.CreateAlbumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	album, err := h.useCase.Create(ctx, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to create album")
+		return
+	}
+
+	h.logger.Info(ctx, "Album successfully created", zap.Int64("id", album.ID))
+	c.JSON(http.StatusCreated, album)
+}
+
+// Update godoc
+// @Summary Update an album
+// @Description Updates an album by ID
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Album ID"
+// @Param request body dto.UpdateAlbumRequest true "Update data"
+// @Success 200 {object} dto.AlbumResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/albums/{id} [put]
+func (h *AlbumHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAlbumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	album, err := h.useCase.Update(ctx, id, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update album")
+		return
+	}
+
+	h.logger.Info(ctx, "Album successfully updated", zap.Int64("id", id))
+	c.JSON(http.StatusOK, album)
+}
+
+// Delete godoc
+// @Summary Delete an album
+// @Description Deletes an album and its track list; the songs themselves are kept
+// @Tags albums
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Album ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/albums/{id} [delete]
+func (h *AlbumHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(ctx, id); err != nil {
+		h.writeError(c, err, "Failed to delete album")
+		return
+	}
+
+	h.logger.Info(ctx, "Album successfully deleted", zap.Int64("id", id))
+	c.Status(http.StatusNoContent)
+}
+
+// Get godoc
+// @Summary Get an album
+// @Description Gets an album by ID
+// @Tags albums
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 200 {object} dto.AlbumResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/albums/{id} [get]
+func (h *AlbumHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	album, err := h.useCase.Get(ctx, id)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve album")
+		return
+	}
+
+	c.JSON(http.StatusOK, album)
+}
+
+// List godoc
+// @Summary List of albums
+// @Description Gets a list of albums with filtering and pagination
+// @Tags albums
+// @Produce json
+// @Param artist_id query int false "Artist ID"
+// @Param title query string false "Album title"
+// @Param type query string false "Album type" Enums(LP, EP, single)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.AlbumListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/albums [get]
+func (h *AlbumHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.AlbumListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	albums, err := h.useCase.List(ctx, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve album list")
+		return
+	}
+
+	c.JSON(http.StatusOK, albums)
+}
+
+// ListTracks godoc
+// @Summary Album tracks
+// @Description Returns the album's songs ordered by disc and track number
+// @Tags albums
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 200 {object} dto.AlbumTracksResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/albums/{id}/tracks [get]
+func (h *AlbumHandler) ListTracks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	tracks, err := h.useCase.ListTracks(ctx, id)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve album tracks")
+		return
+	}
+
+	c.JSON(http.StatusOK, tracks)
+}
+
+// SetTrack godoc
+// @Summary Add or move an album track
+// @Description Puts a song on the album at the given disc and track number
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Album ID"
+// @Param song_id path int true "Song ID"
+// @Param request body dto.SetAlbumTrackRequest true "Track position"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/albums/{id}/tracks/{song_id} [put]
+func (h *AlbumHandler) SetTrack(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	albumID, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+	songID, ok := h.parseID(c, "song_id")
+	if !ok {
+		return
+	}
+
+	var req dto.SetAlbumTrackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.useCase.SetTrack(ctx, albumID, songID, &req); err != nil {
+		h.writeError(c, err, "Failed to set album track")
+		return
+	}
+
+	h.logger.Info(ctx, "Album track successfully set",
+		zap.Int64("album_id", albumID),
+		zap.Int64("song_id", songID))
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveTrack godoc
+// @Summary Remove an album track
+// @Description Removes a song from the album
+// @Tags albums
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Album ID"
+// @Param song_id path int true "Song ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/albums/{id}/tracks/{song_id} [delete]
+func (h *AlbumHandler) RemoveTrack(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	albumID, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+	songID, ok := h.parseID(c, "song_id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.RemoveTrack(ctx, albumID, songID); err != nil {
+		h.writeError(c, err, "Failed to remove album track")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AlbumHandler) parseID(c *gin.Context, param string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Failed to parse ID", zap.String("param", param), zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid " + param})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *AlbumHandler) writeError(c *gin.Context, err error, message string) {
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidDate):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, repository.ErrAlbumNotFound):
+		h.logger.Warn(ctx, "Album not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "album not found"})
+	case errors.Is(err, repository.ErrArtistNotFound):
+		h.logger.Warn(ctx, "Artist not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "artist not found"})
+	case errors.Is(err, repository.ErrSongNotFound):
+		h.logger.Warn(ctx, "Song not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
+	case errors.Is(err, repository.ErrTrackNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "track not found on album"})
+	case errors.Is(err, repository.ErrAlbumAlreadyExists):
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "artist already has an album with this title"})
+	case errors.Is(err, repository.ErrTrackPositionTaken):
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "track position already taken"})
+	default:
+		h.logger.Error(ctx, message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+type ArtistHandler struct {
+	useCase usecase.ArtistUseCase
+	logger  *logger.Logger
+}
+
+func NewArtistHandler(useCase usecase.ArtistUseCase, logger *logger.Logger) *ArtistHandler {
+	return &ArtistHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Create godoc
+// @Summary Create an artist
+// @Description Creates a new artist (group). Names are unique ignoring case and extra whitespace
+// @Tags artists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param request body dto.CreateArtistRequest true "Artist data"
+// @Success 201 {object} dto.ArtistResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/artists [post]
+func (h *ArtistHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.CreateArtistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	artist, err := h.useCase.Create(ctx, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to create artist")
+		return
+	}
+
+	h.logger.Info(ctx, "Artist successfully created", zap.Int64("id", artist.ID))
+	c.JSON(http.StatusCreated, artist)
+}
+
+// Update godoc
+// @Summary Update an artist
+// @Description Updates an artist by ID. Renaming also updates group_name of the artist's songs
+// @Tags artists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Artist ID"
+// @Param request body dto.UpdateArtistRequest true "Update data"
+// @Success 200 {object} dto.ArtistResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/artists/{id} [put]
+func (h *ArtistHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateArtistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	artist, err := h.useCase.Update(ctx, id, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update artist")
+		return
+	}
+
+	h.logger.Info(ctx, "Artist successfully updated", zap.Int64("id", id))
+	c.JSON(http.StatusOK, artist)
+}
+
+// Delete godoc
+// @Summary Delete an artist
+// @Description Deletes an artist that has no songs or albums
+// @Tags artists
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Artist ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/artists/{id} [delete]
+func (h *ArtistHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(ctx, id); err != nil {
+		h.writeError(c, err, "Failed to delete artist")
+		return
+	}
+
+	h.logger.Info(ctx, "Artist successfully deleted", zap.Int64("id", id))
+	c.Status(http.StatusNoContent)
+}
+
+// Get godoc
+// @Summary Get an artist
+// @Description Gets an artist by ID
+// @Tags artists
+// @Produce json
+// @Param id path int true "Artist ID"
+// @Success 200 {object} dto.ArtistResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/artists/{id} [get]
+func (h *ArtistHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	artist, err := h.useCase.Get(ctx, id)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve artist")
+		return
+	}
+
+	c.JSON(http.StatusOK, artist)
+}
+
+// List godoc
+// @Summary List of artists
+// @Description Gets a list of artists with filtering and pagination
+// @Tags artists
+// @Produce json
+// @Param name query string false "Artist name"
+// @Param country query string false "Country"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.ArtistListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/artists [get]
+func (h *ArtistHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.ArtistListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	artists, err := h.useCase.List(ctx, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve artist list")
+		return
+	}
+
+	c.JSON(http.StatusOK, artists)
+}
+
+// ListSongs godoc
+// @Summary Songs of an artist
+// @Description Gets the songs that reference the artist, with pagination
+// @Tags artists
+// @Produce json
+// @Param id path int true "Artist ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.SongListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/artists/{id}/songs [get]
+func (h *ArtistHandler) ListSongs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ArtistSongsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	songs, err := h.useCase.ListSongs(ctx, id, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve artist songs")
+		return
+	}
+
+	c.JSON(http.StatusOK, songs)
+}
+
+func (h *ArtistHandler) parseID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Failed to parse ID", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *ArtistHandler) writeError(c *gin.Context, err error, message string) {
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(err, repository.ErrArtistNotFound):
+		h.logger.Warn(ctx, "Artist not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "artist not found"})
+	case errors.Is(err, repository.ErrArtistAlreadyExists):
+		h.logger.Warn(ctx, "Artist already exists", zap.Error(err))
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "artist with this name already exists"})
+	case errors.Is(err, repository.ErrArtistInUse):
+		h.logger.Warn(ctx, "Artist still in use", zap.Error(err))
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "artist still has songs or albums"})
+	default:
+		h.logger.Error(ctx, message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
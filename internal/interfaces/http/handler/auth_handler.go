@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+)
+
+// Register godoc
+// @Summary Register a user
+// @Description Creates a user with the reader role and logs it in. Usernames are unique ignoring case and may contain letters, digits, '.', '_' and '-'
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RegisterRequest true "Username and password"
+// @Success 201 {object} dto.TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/auth/register [post]
+func (h *UserHandler) Register(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tokens, err := h.useCase.Register(ctx, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to register user")
+		return
+	}
+
+	h.logger.Info(ctx, "User successfully registered", zap.Int64("id", tokens.User.ID))
+	c.JSON(http.StatusCreated, tokens)
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Returns a short-lived access token to send as "Authorization: Bearer" and a refresh token to get the next one
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginRequest true "Username and password"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/auth/login [post]
+func (h *UserHandler) Login(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tokens, err := h.useCase.Login(ctx, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to log in")
+		return
+	}
+
+	h.logger.Info(ctx, "User successfully logged in", zap.Int64("id", tokens.User.ID))
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchanges a refresh token for a new access token and a new refresh token. Each refresh token works once; using one again revokes every token of that login
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshRequest true "Refresh token"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/auth/refresh [post]
+func (h *UserHandler) Refresh(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tokens, err := h.useCase.Refresh(ctx, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to refresh tokens")
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revokes the refresh token and every token rotated from the same login. Access tokens already issued stay valid until they expire
+// @Tags auth
+// @Accept json
+// @Param request body dto.RefreshRequest true "Refresh token"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/logout [post]
+func (h *UserHandler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.useCase.Logout(ctx, &req); err != nil {
+		h.writeError(c, err, "Failed to log out")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
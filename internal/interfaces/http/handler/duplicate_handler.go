@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+	"song-library/internal/domain/repository"
+)
+
+// ListDuplicates godoc
+// @Summary List likely duplicate songs
+// @Description Reports groups of songs that are probably the same song. mode=normalized groups songs whose group and song names are equal ignoring case, accents, spacing and punctuation; mode=fuzzy pairs songs whose group and song names are both at least min_similarity similar by trigrams, closest pairs first
+// @Tags songs
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param mode query string false "How duplicates are detected" Enums(normalized, fuzzy) default(normalized)
+// @Param min_similarity query number false "Trigram similarity, above 0 and at most 1, that fuzzy mode needs" default(0.6)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.DuplicateListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/duplicates [get]
+func (h *SongHandler) ListDuplicates(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.DuplicateListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response, err := h.useCase.Duplicates(ctx, &req)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list duplicate songs", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info(ctx, "Duplicate songs listed",
+		zap.String("mode", req.Mode),
+		zap.Int("total", response.Total))
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Merge godoc
+// @Summary Merge duplicate songs
+// @Description Keeps the song and folds the listed duplicates into it in one transaction. Its empty release date, text and link are filled from the duplicates in the given order; their translations, synced lyrics and album tracks move over unless the song already has its own; then the duplicates and their revisions are deleted. The song's previous values are kept as a revision
+// @Tags songs
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "ID of the song to keep"
+// @Param request body dto.MergeSongsRequest true "Songs to merge into it"
+// @Success 200 {object} dto.SongResponse
+// @Header 200 {string} ETag "Version of the merged song"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/merge [post]
+func (h *SongHandler) Merge(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MergeSongsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	song, err := h.useCase.Merge(ctx, id, &req, editorFrom(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidMerge):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, repository.ErrSongNotFound):
+			h.logger.Warn(ctx, "Song to merge not found", zap.Error(err))
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		default:
+			h.logger.Error(ctx, "Failed to merge songs", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	h.logger.Info(ctx, "Songs successfully merged",
+		zap.Int64("id", id),
+		zap.Int("merged", len(req.DuplicateIDs)))
+	c.Header("ETag", songETag(song))
+	c.JSON(http.StatusOK, song)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/repository"
+)
+
+// songETag derives a strong ETag from the song's row version and a hash of
+// its favorite, rating and play aggregates, which change without a new
+// version. Only the version part is compared for If-Match.
+func songETag(song *dto.SongResponse) string {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%d/%d/%g/%d", song.FavoriteCount, song.RatingCount, song.AverageRating, song.PlayCount)
+	return fmt.Sprintf(`"%d-%08x"`, song.Version, h.Sum32())
+}
+
+// ifMatchVersions parses an If-Match header into song versions, ignoring the
+// aggregate hash of the entity tags. wildcard is true for "*". Weak and
+// foreign entity tags never match and are skipped.
+func ifMatchVersions(header string) (versions []int, wildcard bool) {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		value, _, _ := strings.Cut(tag[1:len(tag)-1], "-")
+		if version, err := strconv.Atoi(value); err == nil && version > 0 {
+			versions = append(versions, version)
+		}
+	}
+	return versions, false
+}
+
+// noneMatch reports whether an If-None-Match header matches etag using the
+// weak comparison required for GET.
+func noneMatch(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// expectedVersion turns the If-Match header into the version a write must
+// be checked against; 0 means the write is unconditional. When the header
+// lists several entity tags the current version decides which one applies,
+// so a missing song is reported as such rather than as a failed precondition.
+func (h *SongHandler) expectedVersion(c *gin.Context, id int64) (int, bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return 0, true
+	}
+
+	versions, wildcard := ifMatchVersions(header)
+	switch {
+	case wildcard:
+		return 0, true
+	case len(versions) == 1:
+		return versions[0], true
+	case len(versions) > 1:
+		song, err := h.useCase.Get(c.Request.Context(), id)
+		if err != nil {
+			if errors.Is(err, repository.ErrSongNotFound) {
+				c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
+				return 0, false
+			}
+			h.logger.Error(c.Request.Context(), "Failed to retrieve song", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return 0, false
+		}
+		for _, version := range versions {
+			if version == song.Version {
+				return version, true
+			}
+		}
+	}
+
+	c.JSON(http.StatusPreconditionFailed, ErrorResponse{Error: "If-Match does not match the current version of the song"})
+	return 0, false
+}
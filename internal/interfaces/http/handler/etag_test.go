@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+	"song-library/internal/domain/entity"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+func TestSongETagFollowsAggregates(t *testing.T) {
+	song := dto.SongResponse{Version: 3, FavoriteCount: 1, RatingCount: 2, AverageRating: 4.5, PlayCount: 10}
+	etag := songETag(&song)
+
+	if versions, _ := ifMatchVersions(etag); !reflect.DeepEqual(versions, []int{3}) {
+		t.Errorf("ifMatchVersions(%s) = %v, want [3]", etag, versions)
+	}
+
+	changes := map[string]func(*dto.SongResponse){
+		"version":        func(s *dto.SongResponse) { s.Version++ },
+		"favorite count": func(s *dto.SongResponse) { s.FavoriteCount++ },
+		"rating count":   func(s *dto.SongResponse) { s.RatingCount++ },
+		"average rating": func(s *dto.SongResponse) { s.AverageRating = 4 },
+		"play count":     func(s *dto.SongResponse) { s.PlayCount++ },
+	}
+	for name, change := range changes {
+		changed := song
+		change(&changed)
+		if songETag(&changed) == etag {
+			t.Errorf("ETag did not change with the %s", name)
+		}
+	}
+}
+
+func TestIfMatchVersions(t *testing.T) {
+	tests := []struct {
+		header       string
+		wantVersions []int
+		wantWildcard bool
+	}{
+		{header: `"3"`, wantVersions: []int{3}},
+		{header: `"3-0badf00d"`, wantVersions: []int{3}},
+		{header: `"3-0badf00d", "4-12345678"`, wantVersions: []int{3, 4}},
+		{header: `*`, wantWildcard: true},
+		{header: `W/"3-0badf00d"`},
+		{header: `"abc"`},
+		{header: `"0"`},
+	}
+
+	for _, tt := range tests {
+		versions, wildcard := ifMatchVersions(tt.header)
+		if !reflect.DeepEqual(versions, tt.wantVersions) || wildcard != tt.wantWildcard {
+			t.Errorf("ifMatchVersions(%s) = %v, %v, want %v, %v",
+				tt.header, versions, wildcard, tt.wantVersions, tt.wantWildcard)
+		}
+	}
+}
+
+func TestNoneMatch(t *testing.T) {
+	etag := `"3-0badf00d"`
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: `"3-0badf00d"`, want: true},
+		{header: `W/"3-0badf00d"`, want: true},
+		{header: `"2-0badf00d", "3-0badf00d"`, want: true},
+		{header: `*`, want: true},
+		{header: `"3-12345678"`, want: false},
+		{header: `"3"`, want: false},
+		{header: ``, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := noneMatch(tt.header, etag); got != tt.want {
+			t.Errorf("noneMatch(%s) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+// versionRepo answers GetByID with the stored song, or ErrSongNotFound.
+// Other methods are not needed and panic through the nil embedded interface.
+type versionRepo struct {
+	repository.SongRepository
+	song *entity.Song
+}
+
+func (r *versionRepo) GetByID(ctx context.Context, id int64) (*entity.Song, error) {
+	if r.song == nil || r.song.ID != id {
+		return nil, repository.ErrSongNotFound
+	}
+	return r.song, nil
+}
+
+func TestExpectedVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		song     *entity.Song
+		ifMatch  string
+		wantCode int
+	}{
+		{name: "one tag of a missing song is left to the write", ifMatch: `"3-0badf00d"`, wantCode: http.StatusNoContent},
+		{name: "several tags of a missing song", ifMatch: `"3-0badf00d", "4-12345678"`, wantCode: http.StatusNotFound},
+		{name: "several tags, one current", song: &entity.Song{ID: 1, Version: 4}, ifMatch: `"3-0badf00d", "4-12345678"`, wantCode: http.StatusNoContent},
+		{name: "several tags, none current", song: &entity.Song{ID: 1, Version: 5}, ifMatch: `"3-0badf00d", "4-12345678"`, wantCode: http.StatusPreconditionFailed},
+		{name: "only foreign tags", song: &entity.Song{ID: 1, Version: 5}, ifMatch: `W/"5-0badf00d"`, wantCode: http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewSongHandler(*usecase.NewSongUseCase(&versionRepo{song: tt.song}, nil, nil), logger.New("error"))
+			router := gin.New()
+			router.DELETE("/songs/:id", func(c *gin.Context) {
+				if _, ok := h.expectedVersion(c, 1); ok {
+					c.Status(http.StatusNoContent)
+				}
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/songs/1", nil)
+			req.Header.Set("If-Match", tt.ifMatch)
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("If-Match %s = %d, want %d: %s", tt.ifMatch, w.Code, tt.wantCode, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+)
+
+var exportContentTypes = map[usecase.ExportFormat]string{
+	usecase.ExportNDJSON: "application/x-ndjson",
+	usecase.ExportCSV:    "text/csv; charset=utf-8",
+	usecase.ExportJSON:   "application/json; charset=utf-8",
+	usecase.ExportM3U:    "audio/x-mpegurl; charset=utf-8",
+	usecase.ExportXSPF:   "application/xspf+xml; charset=utf-8",
+	usecase.ExportJSPF:   "application/jspf+json; charset=utf-8",
+}
+
+// Export godoc
+// @Summary Export songs
+// @Description Streams every song, or the songs matching the list filters, as NDJSON, CSV or JSON, or as an extended M3U, XSPF or JSPF playlist of their links. M3U leaves out songs without a link. Songs are read in batches by id, so the export holds no long transaction and may include changes made while it runs
+// @Tags songs
+// @Produce json
+// @Produce plain
+// @Param format query string false "Export format" Enums(ndjson, csv, json, m3u, xspf, jspf) default(ndjson)
+// @Param artist_id query int false "Artist ID"
+// @Param group_name query string false "Group name"
+// @Param song_name query string false "Song name"
+// @Param release_date query string false "Release date (YYYY-MM-DD)"
+// @Param text query string false "Text fragment"
+// @Param link query string false "Link fragment"
+// @Param name_match query string false "How group_name and song_name match, ignoring case; fuzzy tolerates typos" Enums(contains, prefix, exact, fuzzy) default(contains)
+// @Param min_similarity query number false "Trigram similarity, above 0 and at most 1, that a fuzzy match needs" default(0.3)
+// @Param released_from query string false "Released on or after (YYYY-MM-DD)"
+// @Param released_to query string false "Released on or before (YYYY-MM-DD)"
+// @Param year query int false "Release year"
+// @Param decade query int false "Release decade, e.g. 1990"
+// @Param has_lyrics query bool false "Only songs with (true) or without (false) lyrics"
+// @Param has_link query bool false "Only songs with (true) or without (false) a link"
+// @Param created_from query string false "Created at or after (RFC 3339)"
+// @Param created_to query string false "Created at or before (RFC 3339)"
+// @Param updated_from query string false "Updated at or after (RFC 3339)"
+// @Param updated_to query string false "Updated at or before (RFC 3339)"
+// @Param min_average_rating query number false "Average rating of at least (0 to 5)"
+// @Param min_rating_count query int false "Rated at least this many times"
+// @Param min_favorite_count query int false "Favorited at least this many times"
+// @Param min_play_count query int false "Played at least this many times"
+// @Success 200 {string} string "Exported songs"
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/export [get]
+func (h *SongHandler) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.SongExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	format := usecase.ExportFormat(req.Format)
+	c.Header("Content-Type", exportContentTypes[format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="songs.%s"`, format))
+
+	count, err := h.useCase.Export(ctx, &req.SongListRequest, format, c.Writer)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to export songs", zap.Error(err), zap.Int("exported", count))
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			status := http.StatusInternalServerError
+			if errors.Is(err, usecase.ErrInvalidFilter) {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	h.logger.Info(ctx, "Songs successfully exported",
+		zap.String("format", req.Format),
+		zap.Int("songs", count))
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+	"song-library/internal/domain/repository"
+	"song-library/internal/infrastructure/auth"
+	"song-library/pkg/logger"
+)
+
+type FeedbackHandler struct {
+	useCase usecase.FeedbackUseCase
+	logger  *logger.Logger
+}
+
+func NewFeedbackHandler(useCase usecase.FeedbackUseCase, logger *logger.Logger) *FeedbackHandler {
+	return &FeedbackHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// ListFavorites godoc
+// @Summary List favorite songs
+// @Description Gets the logged-in user's favorite songs, most recently added first. Songs in the trash are left out until they are restored
+// @Tags feedback
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.FavoriteListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/favorites [get]
+func (h *FeedbackHandler) ListFavorites(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.FavoriteListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	favorites, err := h.useCase.ListFavorites(ctx, auth.PrincipalFromContext(ctx), &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve favorites")
+		return
+	}
+
+	c.JSON(http.StatusOK, favorites)
+}
+
+// Get godoc
+// @Summary Get your feedback on a song
+// @Description Gets whether the logged-in user has favorited the song, their rating and the song's aggregates
+// @Tags feedback
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Success 200 {object} dto.SongFeedbackResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/feedback [get]
+func (h *FeedbackHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	feedback, err := h.useCase.Get(ctx, auth.PrincipalFromContext(ctx), id)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve song feedback")
+		return
+	}
+
+	c.JSON(http.StatusOK, feedback)
+}
+
+// AddFavorite godoc
+// @Summary Favorite a song
+// @Description Adds the song to the logged-in user's favorites. Favoriting a song twice changes nothing
+// @Tags feedback
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Success 200 {object} dto.SongStatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/favorite [put]
+func (h *FeedbackHandler) AddFavorite(c *gin.Context) {
+	h.setFavorite(c, true)
+}
+
+// RemoveFavorite godoc
+// @Summary Unfavorite a song
+// @Description Removes the song from the logged-in user's favorites
+// @Tags feedback
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Success 200 {object} dto.SongStatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/favorite [delete]
+func (h *FeedbackHandler) RemoveFavorite(c *gin.Context) {
+	h.setFavorite(c, false)
+}
+
+func (h *FeedbackHandler) setFavorite(c *gin.Context, favorite bool) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.useCase.SetFavorite(ctx, auth.PrincipalFromContext(ctx), id, favorite)
+	if err != nil {
+		h.writeError(c, err, "Failed to update favorite")
+		return
+	}
+
+	h.logger.Info(ctx, "Favorite successfully updated",
+		zap.Int64("song_id", id),
+		zap.Bool("favorite", favorite))
+	c.JSON(http.StatusOK, stats)
+}
+
+// Rate godoc
+// @Summary Rate a song
+// @Description Sets the logged-in user's rating of the song from 1 to 5, replacing an earlier rating
+// @Tags feedback
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Param request body dto.RateSongRequest true "Rating"
+// @Success 200 {object} dto.SongStatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/rating [put]
+func (h *FeedbackHandler) Rate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RateSongRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	stats, err := h.useCase.Rate(ctx, auth.PrincipalFromContext(ctx), id, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to rate song")
+		return
+	}
+
+	h.logger.Info(ctx, "Song successfully rated",
+		zap.Int64("song_id", id),
+		zap.Int("rating", req.Rating))
+	c.JSON(http.StatusOK, stats)
+}
+
+// RemoveRating godoc
+// @Summary Remove your rating of a song
+// @Description Withdraws the logged-in user's rating of the song
+// @Tags feedback
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Success 200 {object} dto.SongStatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/rating [delete]
+func (h *FeedbackHandler) RemoveRating(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.useCase.RemoveRating(ctx, auth.PrincipalFromContext(ctx), id)
+	if err != nil {
+		h.writeError(c, err, "Failed to remove rating")
+		return
+	}
+
+	h.logger.Info(ctx, "Rating successfully removed", zap.Int64("song_id", id))
+	c.JSON(http.StatusOK, stats)
+}
+
+// RecordPlay godoc
+// @Summary Record a play
+// @Description Counts one play of the song. Plays by logged-in users are recorded against their account
+// @Tags feedback
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Success 201 {object} dto.SongStatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/plays [post]
+func (h *FeedbackHandler) RecordPlay(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.useCase.RecordPlay(ctx, auth.PrincipalFromContext(ctx), id)
+	if err != nil {
+		h.writeError(c, err, "Failed to record play")
+		return
+	}
+
+	c.JSON(http.StatusCreated, stats)
+}
+
+func (h *FeedbackHandler) parseID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Failed to parse ID", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *FeedbackHandler) writeError(c *gin.Context, err error, message string) {
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(err, usecase.ErrFeedbackNeedsUser):
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: usecase.ErrFeedbackNeedsUser.Error()})
+	case errors.Is(err, repository.ErrSongNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
+	default:
+		h.logger.Error(ctx, message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
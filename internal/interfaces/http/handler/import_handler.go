@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+)
+
+const maxImportSize = 32 << 20
+
+// Import godoc
+// @Summary Import songs in bulk
+// @Description Creates songs from a CSV file with a header row (columns group, song, release_date, text, link), a JSON array or NDJSON with the same keys. The format comes from the format parameter or the Content-Type (text/csv, application/json, application/x-ndjson). Every row is validated on its own; rows whose normalised group and title are already in the library or earlier in the file are skipped. Songs are inserted in batched transactions, so rows before a malformed part of the file stay imported. With enrich=true the music info API fills the fields a row leaves empty; otherwise songs are stored as given
+// @Tags songs
+// @Accept plain
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param format query string false "File format, overrides the Content-Type" Enums(csv, json, ndjson)
+// @Param enrich query bool false "Queue the imported songs for enrichment" default(false)
+// @Param file body string true "Songs to import"
+// @Success 200 {object} dto.ImportReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/import [post]
+func (h *SongHandler) Import(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.ImportSongsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	format := usecase.ImportFormat(req.Format)
+	if format == "" {
+		switch c.ContentType() {
+		case "text/csv":
+			format = usecase.ImportCSV
+		case "application/json":
+			format = usecase.ImportJSON
+		case "application/x-ndjson", "application/ndjson", "application/jsonl":
+			format = usecase.ImportNDJSON
+		default:
+			c.JSON(http.StatusUnsupportedMediaType, ErrorResponse{Error: "use text/csv, application/json or application/x-ndjson, or set the format parameter"})
+			return
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxImportSize+1))
+	if err != nil {
+		h.logger.Error(ctx, "Failed to read request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "failed to read request body"})
+		return
+	}
+	if len(data) > maxImportSize {
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{Error: fmt.Sprintf("import file must not exceed %d bytes", maxImportSize)})
+		return
+	}
+
+	report, err := h.useCase.Import(ctx, bytes.NewReader(data), format, req.Enrich)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidImport) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		h.logger.Error(ctx, "Failed to import songs", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info(ctx, "Songs successfully imported",
+		zap.Int("created", report.Created),
+		zap.Int("skipped", report.Skipped),
+		zap.Int("failed", report.Failed))
+	c.JSON(http.StatusOK, report)
+}
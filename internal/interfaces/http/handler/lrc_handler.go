@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/lyrics"
+	"song-library/internal/domain/repository"
+)
+
+const maxLRCSize = 1 << 20
+
+// ImportLRC godoc
+// @Summary Upload time-synced lyrics
+// @Description Replaces the song's synced lyrics with an LRC file. Enhanced LRC with per-word <mm:ss.xx> timestamps is supported. Lines with several timestamps are repeated at each of them; lines must not overlap
+// @Tags lyrics
+// @Accept plain
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Param lrc body string true "LRC file"
+// @Success 200 {object} dto.SyncedLyricsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/lrc [put]
+func (h *SongHandler) ImportLRC(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxLRCSize+1))
+	if err != nil {
+		h.logger.Error(ctx, "Failed to read request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "failed to read request body"})
+		return
+	}
+	if len(data) > maxLRCSize {
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{Error: fmt.Sprintf("LRC file must not exceed %d bytes", maxLRCSize)})
+		return
+	}
+
+	response, err := h.useCase.ImportLRC(ctx, id, string(data))
+	if err != nil {
+		h.writeLyricsError(c, id, err, "Failed to import LRC")
+		return
+	}
+
+	h.logger.Info(ctx, "Synced lyrics successfully imported",
+		zap.Int64("id", id),
+		zap.Int("lines", response.TotalLines))
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ExportLRC godoc
+// @Summary Download time-synced lyrics
+// @Description Returns the song's synced lyrics as an LRC file
+// @Tags lyrics
+// @Produce plain
+// @Param id path int true "Song ID"
+// @Success 200 {string} string "LRC file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/lrc [get]
+func (h *SongHandler) ExportLRC(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+
+	lrc, err := h.useCase.ExportLRC(ctx, id)
+	if err != nil {
+		h.writeLyricsError(c, id, err, "Failed to export LRC")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="song-%d.lrc"`, id))
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(lrc))
+}
+
+// DeleteLRC godoc
+// @Summary Delete time-synced lyrics
+// @Description Removes the song's synced lyrics; the plain text is kept
+// @Tags lyrics
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/lrc [delete]
+func (h *SongHandler) DeleteLRC(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.DeleteSyncedLyrics(ctx, id); err != nil {
+		h.writeLyricsError(c, id, err, "Failed to delete synced lyrics")
+		return
+	}
+
+	h.logger.Info(ctx, "Synced lyrics successfully deleted", zap.Int64("id", id))
+	c.Status(http.StatusNoContent)
+}
+
+// GetActiveLine godoc
+// @Summary Get the line active at a playback position
+// @Description Returns the synced line being sung at the given offset and the line after it. line is null before the first line starts and during instrumental gaps
+// @Tags lyrics
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param at query int false "Playback position in milliseconds" default(0)
+// @Success 200 {object} dto.ActiveLineResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/lrc/active [get]
+func (h *SongHandler) GetActiveLine(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ActiveLineRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response, err := h.useCase.GetActiveLine(ctx, id, &req)
+	if err != nil {
+		h.writeLyricsError(c, id, err, "Failed to get active line")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *SongHandler) parseSongID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Failed to parse ID", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid ID format"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *SongHandler) writeLyricsError(c *gin.Context, id int64, err error, message string) {
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(err, lyrics.ErrInvalidLRC):
+		h.logger.Warn(ctx, "Invalid LRC file", zap.Int64("id", id), zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, repository.ErrSongNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
+	case errors.Is(err, repository.ErrSyncedLyricsNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "song has no synced lyrics"})
+	default:
+		h.logger.Error(ctx, message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+	"song-library/internal/infrastructure/auth"
+)
+
+// Export godoc
+// @Summary Export a playlist
+// @Description Streams the songs of a playlist in order as an extended M3U, XSPF or JSPF playlist named after it, using each song's link as the location and its group and title as creator and title. M3U leaves out songs without a link
+// @Tags playlists
+// @Produce plain
+// @Param id path int true "Playlist ID"
+// @Param format query string false "Playlist format" Enums(m3u, xspf, jspf) default(m3u)
+// @Success 200 {string} string "Exported playlist"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists/{id}/export [get]
+func (h *PlaylistHandler) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.PlaylistExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	format := usecase.ExportFormat(req.Format)
+	c.Header("Content-Type", exportContentTypes[format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="playlist-%d.%s"`, id, format))
+
+	count, err := h.useCase.Export(ctx, auth.PrincipalFromContext(ctx), id, format, c.Writer)
+	if err != nil {
+		if c.Writer.Written() {
+			h.logger.Error(ctx, "Failed to export playlist", zap.Error(err), zap.Int("exported", count))
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		h.writeError(c, err, "Failed to export playlist")
+		return
+	}
+
+	h.logger.Info(ctx, "Playlist successfully exported",
+		zap.Int64("id", id),
+		zap.String("format", req.Format),
+		zap.Int("songs", count))
+}
+
+// Import godoc
+// @Summary Import a playlist file
+// @Description Appends the tracks of an extended M3U, XSPF or JSPF file to a playlist in file order. Tracks are matched against the library by group and title (creator and title, or "Group - Title" in #EXTINF) and otherwise by link; tracks that match no song are reported as unmatched and left out. The format comes from the format parameter or the Content-Type (audio/x-mpegurl, application/xspf+xml, application/jspf+json). Only the owner of the playlist or an admin may import
+// @Tags playlists
+// @Accept plain
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Playlist ID"
+// @Param format query string false "File format, overrides the Content-Type" Enums(m3u, xspf, jspf)
+// @Param file body string true "Playlist file"
+// @Success 200 {object} dto.PlaylistImportReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists/{id}/import [post]
+func (h *PlaylistHandler) Import(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.PlaylistImportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	format := usecase.ImportFormat(req.Format)
+	if format == "" {
+		switch c.ContentType() {
+		case "audio/x-mpegurl", "audio/mpegurl", "application/x-mpegurl", "application/vnd.apple.mpegurl":
+			format = usecase.ImportM3U
+		case "application/xspf+xml":
+			format = usecase.ImportXSPF
+		case "application/jspf+json", "application/json":
+			format = usecase.ImportJSPF
+		default:
+			c.JSON(http.StatusUnsupportedMediaType, ErrorResponse{Error: "use audio/x-mpegurl, application/xspf+xml or application/jspf+json, or set the format parameter"})
+			return
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxImportSize+1))
+	if err != nil {
+		h.logger.Error(ctx, "Failed to read request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "failed to read request body"})
+		return
+	}
+	if len(data) > maxImportSize {
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{Error: fmt.Sprintf("import file must not exceed %d bytes", maxImportSize)})
+		return
+	}
+
+	report, err := h.useCase.Import(ctx, auth.PrincipalFromContext(ctx), id, bytes.NewReader(data), format)
+	if err != nil {
+		h.writeError(c, err, "Failed to import playlist")
+		return
+	}
+
+	h.logger.Info(ctx, "Playlist successfully imported",
+		zap.Int64("id", id),
+		zap.Int("added", report.Added),
+		zap.Int("unmatched", report.Unmatched))
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,392 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+	"song-library/internal/domain/repository"
+	"song-library/internal/infrastructure/auth"
+	"song-library/pkg/logger"
+)
+
+type PlaylistHandler struct {
+	useCase usecase.PlaylistUseCase
+	logger  *logger.Logger
+}
+
+func NewPlaylistHandler(useCase usecase.PlaylistUseCase, logger *logger.Logger) *PlaylistHandler {
+	return &PlaylistHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Create godoc
+// @Summary Create a playlist
+// @Description Creates a playlist owned by the logged-in user. Playlists are private unless visibility is public
+// @Tags playlists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreatePlaylistRequest true "Playlist data"
+// @Success 201 {object} dto.PlaylistResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists [post]
+func (h *PlaylistHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.CreatePlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	playlist, err := h.useCase.Create(ctx, auth.PrincipalFromContext(ctx), &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to create playlist")
+		return
+	}
+
+	h.logger.Info(ctx, "Playlist successfully created", zap.Int64("id", playlist.ID))
+	c.JSON(http.StatusCreated, playlist)
+}
+
+// Update godoc
+// @Summary Update a playlist
+// @Description Changes the name, description and visibility of a playlist. Only its owner or an admin may
+// @Tags playlists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Playlist ID"
+// @Param request body dto.UpdatePlaylistRequest true "Update data"
+// @Success 200 {object} dto.PlaylistResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists/{id} [put]
+func (h *PlaylistHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdatePlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	playlist, err := h.useCase.Update(ctx, auth.PrincipalFromContext(ctx), id, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update playlist")
+		return
+	}
+
+	h.logger.Info(ctx, "Playlist successfully updated", zap.Int64("id", id))
+	c.JSON(http.StatusOK, playlist)
+}
+
+// Delete godoc
+// @Summary Delete a playlist
+// @Description Deletes a playlist and its items; the songs are kept. Only its owner or an admin may
+// @Tags playlists
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Playlist ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists/{id} [delete]
+func (h *PlaylistHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(ctx, auth.PrincipalFromContext(ctx), id); err != nil {
+		h.writeError(c, err, "Failed to delete playlist")
+		return
+	}
+
+	h.logger.Info(ctx, "Playlist successfully deleted", zap.Int64("id", id))
+	c.Status(http.StatusNoContent)
+}
+
+// Get godoc
+// @Summary Get a playlist
+// @Description Gets a playlist by ID. Private playlists are only found by their owner and admins
+// @Tags playlists
+// @Produce json
+// @Param id path int true "Playlist ID"
+// @Success 200 {object} dto.PlaylistResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists/{id} [get]
+func (h *PlaylistHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	playlist, err := h.useCase.Get(ctx, auth.PrincipalFromContext(ctx), id)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve playlist")
+		return
+	}
+
+	c.JSON(http.StatusOK, playlist)
+}
+
+// List godoc
+// @Summary List of playlists
+// @Description Gets the public playlists and the caller's own, most recently changed first
+// @Tags playlists
+// @Produce json
+// @Param name query string false "Part of the playlist name"
+// @Param user_id query int false "Owner ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.PlaylistListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists [get]
+func (h *PlaylistHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.PlaylistListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	playlists, err := h.useCase.List(ctx, auth.PrincipalFromContext(ctx), &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve playlist list")
+		return
+	}
+
+	c.JSON(http.StatusOK, playlists)
+}
+
+// ListItems godoc
+// @Summary Songs of a playlist
+// @Description Gets the items of a playlist in order. Songs in the trash are left out until they are restored. Song texts are empty unless include_lyrics is set
+// @Tags playlists
+// @Produce json
+// @Param id path int true "Playlist ID"
+// @Param include_lyrics query bool false "Include song texts"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(50)
+// @Success 200 {object} dto.PlaylistItemsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists/{id}/items [get]
+func (h *PlaylistHandler) ListItems(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.PlaylistItemsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	items, err := h.useCase.ListItems(ctx, auth.PrincipalFromContext(ctx), id, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve playlist items")
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// AddItem godoc
+// @Summary Add a song to a playlist
+// @Description Appends the song, or places it directly before or after an existing item. A song may appear more than once
+// @Tags playlists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Playlist ID"
+// @Param request body dto.AddPlaylistItemRequest true "Song and position"
+// @Success 201 {object} dto.PlaylistItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists/{id}/items [post]
+func (h *PlaylistHandler) AddItem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.AddPlaylistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	item, err := h.useCase.AddItem(ctx, auth.PrincipalFromContext(ctx), id, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to add playlist item")
+		return
+	}
+
+	h.logger.Info(ctx, "Playlist item successfully added",
+		zap.Int64("playlist_id", id),
+		zap.Int64("id", item.ID))
+	c.JSON(http.StatusCreated, item)
+}
+
+// MoveItem godoc
+// @Summary Move a playlist item
+// @Description Places the item directly before or after another item. Positions are given relative to items rather than as indexes, so moves made at the same time by others do not change what the move means
+// @Tags playlists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Playlist ID"
+// @Param item_id path int true "Item ID"
+// @Param request body dto.MovePlaylistItemRequest true "New position"
+// @Success 200 {object} dto.PlaylistItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists/{id}/items/{item_id}/position [put]
+func (h *PlaylistHandler) MoveItem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := h.parseID(c, "item_id")
+	if !ok {
+		return
+	}
+
+	var req dto.MovePlaylistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	item, err := h.useCase.MoveItem(ctx, auth.PrincipalFromContext(ctx), id, itemID, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to move playlist item")
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// RemoveItem godoc
+// @Summary Remove a playlist item
+// @Description Removes one item from the playlist; other items keep their order
+// @Tags playlists
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Playlist ID"
+// @Param item_id path int true "Item ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/playlists/{id}/items/{item_id} [delete]
+func (h *PlaylistHandler) RemoveItem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := h.parseID(c, "item_id")
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.RemoveItem(ctx, auth.PrincipalFromContext(ctx), id, itemID); err != nil {
+		h.writeError(c, err, "Failed to remove playlist item")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PlaylistHandler) parseID(c *gin.Context, param string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Failed to parse ID", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *PlaylistHandler) writeError(c *gin.Context, err error, message string) {
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidPlaylistAnchor), errors.Is(err, usecase.ErrInvalidImport):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, usecase.ErrPlaylistNeedsUser):
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: usecase.ErrPlaylistNeedsUser.Error()})
+	case errors.Is(err, usecase.ErrPlaylistForbidden):
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: usecase.ErrPlaylistForbidden.Error()})
+	case errors.Is(err, repository.ErrPlaylistNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "playlist not found"})
+	case errors.Is(err, repository.ErrPlaylistItemNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "playlist item not found"})
+	case errors.Is(err, repository.ErrSongNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
+	default:
+		h.logger.Error(ctx, message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
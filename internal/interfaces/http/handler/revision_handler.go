@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/repository"
+	"song-library/internal/infrastructure/auth"
+)
+
+// ListRevisions godoc
+// @Summary List song revisions
+// @Description Returns the previous versions of a song, newest first. Every update stores the values it replaced together with the editor and time of the edit
+// @Tags revisions
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.RevisionListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/revisions [get]
+func (h *SongHandler) ListRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RevisionListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response, err := h.useCase.ListRevisions(ctx, id, &req)
+	if err != nil {
+		h.writeRevisionError(c, err, "Failed to list revisions")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRevision godoc
+// @Summary Get a song revision
+// @Description Returns the values a song had before the given revision was recorded
+// @Tags revisions
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} dto.RevisionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/revisions/{revision} [get]
+func (h *SongHandler) GetRevision(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+	revision, ok := h.parseRevision(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.useCase.GetRevision(ctx, id, revision)
+	if err != nil {
+		h.writeRevisionError(c, err, "Failed to retrieve revision")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DiffRevisions godoc
+// @Summary Compare song revisions
+// @Description Returns changed fields and a line-level diff of the lyrics between two revisions, or between a revision and the current song when to is omitted
+// @Tags revisions
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param from query int true "Older revision number"
+// @Param to query int false "Newer revision number, defaults to the current song"
+// @Success 200 {object} dto.RevisionDiffResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/revisions/diff [get]
+func (h *SongHandler) DiffRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RevisionDiffRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response, err := h.useCase.DiffRevisions(ctx, id, &req)
+	if err != nil {
+		h.writeRevisionError(c, err, "Failed to compare revisions")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RestoreRevision godoc
+// @Summary Restore a song revision
+// @Description Puts the values of an old revision back. The values being replaced are stored as a new revision
+// @Tags revisions
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} dto.SongResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/revisions/{revision}/restore [post]
+func (h *SongHandler) RestoreRevision(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+	revision, ok := h.parseRevision(c)
+	if !ok {
+		return
+	}
+
+	song, err := h.useCase.RestoreRevision(ctx, id, revision, editorFrom(c))
+	if err != nil {
+		h.writeRevisionError(c, err, "Failed to restore revision")
+		return
+	}
+
+	h.logger.Info(ctx, "Song revision successfully restored",
+		zap.Int64("id", id),
+		zap.Int("revision", revision))
+	c.JSON(http.StatusOK, song)
+}
+
+func (h *SongHandler) parseRevision(c *gin.Context) (int, bool) {
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision < 1 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid revision"})
+		return 0, false
+	}
+	return revision, true
+}
+
+func (h *SongHandler) writeRevisionError(c *gin.Context, err error, message string) {
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(err, repository.ErrSongNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
+	case errors.Is(err, repository.ErrRevisionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "revision not found"})
+	case errors.Is(err, repository.ErrSongAlreadyExists):
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "another song with this group and title already exists"})
+	default:
+		h.logger.Error(ctx, message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
+
+// editorFrom names the authenticated caller as the author of an edit.
+func editorFrom(c *gin.Context) string {
+	if principal := auth.PrincipalFromContext(c.Request.Context()); principal != nil {
+		return principal.Subject
+	}
+	return "anonymous"
+}
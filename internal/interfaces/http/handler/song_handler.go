@@ -2,6 +2,8 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -13,9 +15,12 @@ import (
 	"song-library/internal/application/usecase"
 	"song-library/internal/domain/entity"
 	"song-library/internal/domain/repository"
+	"song-library/pkg/jsonpatch"
 	"song-library/pkg/logger"
 )
 
+const maxPatchSize = 1 << 20
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
@@ -34,13 +39,18 @@ func NewSongHandler(useCase usecase.SongUseCase, logger *logger.Logger) *SongHan
 
 // Create godoc
 // @Summary Create a new song
-// @Description Creates a new song based on group and title
+// @Description Creates a new song based on group and title; a song with the same group and title, ignoring case and extra whitespace, is a conflict. Release date, lyrics and link are fetched from the music info API in the background; poll GET /api/v1/songs/{id} for enrichment_status
 // @Tags songs
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param request body dto.CreateSongRequest true "Song data"
 // @Success 201 {object} dto.SongResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/songs [post]
 func (h *SongHandler) Create(c *gin.Context) {
@@ -59,6 +69,10 @@ func (h *SongHandler) Create(c *gin.Context) {
 
 	song, err := h.useCase.Create(ctx, &req)
 	if err != nil {
+		if errors.Is(err, repository.ErrSongAlreadyExists) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: "song already exists"})
+			return
+		}
 		h.logger.Error(ctx, "Failed to create song", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
@@ -69,20 +83,29 @@ func (h *SongHandler) Create(c *gin.Context) {
 		zap.String("group", song.GroupName),
 		zap.String("song", song.SongName))
 		
+	c.Header("Location", fmt.Sprintf("/api/v1/songs/%d", song.ID))
 	c.JSON(http.StatusCreated, song)
 }
 
 // Update godoc
 // @Summary Update a song
-// @Description Updates an existing song by ID
+// @Description Updates an existing song by ID. The previous values are kept as a revision
 // @Tags songs
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param id path int true "Song ID"
 // @Param request body dto.UpdateSongRequest true "Update data"
+// @Param If-Match header string false "ETag of the version being edited"
 // @Success 200 {object} dto.SongResponse
+// @Header 200 {string} ETag "Version of the updated song"
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/songs/{id} [put]
 func (h *SongHandler) Update(c *gin.Context) {
@@ -124,31 +147,135 @@ func (h *SongHandler) Update(c *gin.Context) {
 
 	h.logger.Debug(ctx, "Request data received", zap.Any("song", song))
 
-	updatedSong, err := h.useCase.Update(ctx, id, &req)
+	version, ok := h.expectedVersion(c, id)
+	if !ok {
+		return
+	}
+
+	updatedSong, err := h.useCase.Update(ctx, id, &req, editorFrom(c), version)
 	if err != nil {
 		if errors.Is(err, repository.ErrSongNotFound) {
 			h.logger.Warn(ctx, "Song not found", zap.Int64("id", id))
 			c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
 			return
 		}
+		if errors.Is(err, repository.ErrSongVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, ErrorResponse{Error: "song was modified by someone else, fetch it again"})
+			return
+		}
+		if errors.Is(err, repository.ErrSongAlreadyExists) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: "another song with this group and title already exists"})
+			return
+		}
 		h.logger.Error(ctx, "Failed to update song", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	h.logger.Info(ctx, "Song successfully updated", zap.Int64("id", id))
+	c.Header("ETag", songETag(updatedSong))
 	c.JSON(http.StatusOK, updatedSong)
 }
 
+// Patch godoc
+// @Summary Partially update a song
+// @Description Applies an RFC 7386 merge patch (application/merge-patch+json or application/json) or an RFC 6902 JSON Patch (application/json-patch+json) to group_name, song_name, release_date, text and link. Fields the patch does not mention keep their values; null removes release_date, text or link. The previous values are kept as a revision
+// @Tags songs
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Param patch body object true "Merge patch object or JSON Patch operations"
+// @Param If-Match header string false "ETag of the version being edited"
+// @Success 200 {object} dto.SongResponse
+// @Header 200 {string} ETag "Version of the updated song"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id} [patch]
+func (h *SongHandler) Patch(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+
+	var format usecase.PatchFormat
+	switch c.ContentType() {
+	case "application/merge-patch+json", "application/json":
+		format = usecase.MergePatch
+	case "application/json-patch+json":
+		format = usecase.JSONPatch
+	default:
+		c.Header("Accept-Patch", "application/merge-patch+json, application/json-patch+json")
+		c.JSON(http.StatusUnsupportedMediaType, ErrorResponse{Error: "use application/merge-patch+json or application/json-patch+json"})
+		return
+	}
+
+	patch, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPatchSize+1))
+	if err != nil {
+		h.logger.Error(ctx, "Failed to read request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "failed to read request body"})
+		return
+	}
+	if len(patch) > maxPatchSize {
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{Error: fmt.Sprintf("patch must not exceed %d bytes", maxPatchSize)})
+		return
+	}
+
+	version, ok := h.expectedVersion(c, id)
+	if !ok {
+		return
+	}
+
+	song, err := h.useCase.Patch(ctx, id, patch, format, editorFrom(c), version)
+	if err != nil {
+		switch {
+		case errors.Is(err, jsonpatch.ErrInvalidPatch):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, usecase.ErrInvalidSongPatch):
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, repository.ErrSongNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
+		case errors.Is(err, repository.ErrSongVersionConflict):
+			c.JSON(http.StatusPreconditionFailed, ErrorResponse{Error: "song was modified by someone else, fetch it again"})
+		case errors.Is(err, repository.ErrSongAlreadyExists):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: "another song with this group and title already exists"})
+		default:
+			h.logger.Error(ctx, "Failed to patch song", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	h.logger.Info(ctx, "Song successfully patched", zap.Int64("id", id))
+	c.Header("ETag", songETag(song))
+	c.JSON(http.StatusOK, song)
+}
+
 // Delete godoc
 // @Summary Delete a song
-// @Description Deletes a song by ID
+// @Description Moves a song to the trash. It is hidden from then on and can be restored with POST /api/v1/songs/{id}/restore until the trash is purged
 // @Tags songs
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param id path int true "Song ID"
+// @Param If-Match header string false "ETag of the version being deleted"
 // @Success 204 "No Content"
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/songs/{id} [delete]
 func (h *SongHandler) Delete(c *gin.Context) {
@@ -163,12 +290,21 @@ func (h *SongHandler) Delete(c *gin.Context) {
 
 	h.logger.Debug(ctx, "Starting song deletion request processing", zap.Int64("id", id))
 
-	if err := h.useCase.Delete(ctx, id); err != nil {
+	version, ok := h.expectedVersion(c, id)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(ctx, id, version); err != nil {
 		if errors.Is(err, repository.ErrSongNotFound) {
 			h.logger.Warn(ctx, "Song not found during deletion attempt", zap.Int64("id", id))
 			c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
 			return
 		}
+		if errors.Is(err, repository.ErrSongVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, ErrorResponse{Error: "song was modified by someone else, fetch it again"})
+			return
+		}
 		h.logger.Error(ctx, "Failed to delete song", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
@@ -180,11 +316,14 @@ func (h *SongHandler) Delete(c *gin.Context) {
 
 // Get godoc
 // @Summary Get a song
-// @Description Gets a song by ID
+// @Description Gets a song by ID, including its enrichment_status (pending, done or failed)
 // @Tags songs
 // @Produce json
 // @Param id path int true "Song ID"
+// @Param If-None-Match header string false "ETag of a cached copy"
 // @Success 200 {object} dto.SongResponse
+// @Header 200 {string} ETag "Version of the song and a hash of its favorite, rating and play counts"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -213,19 +352,49 @@ func (h *SongHandler) Get(c *gin.Context) {
 		return
 	}
 
+	etag := songETag(song)
+	c.Header("ETag", etag)
+	if noneMatch(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	h.logger.Info(ctx, "Song successfully retrieved", zap.Int64("id", song.ID))
 	c.JSON(http.StatusOK, song)
 }
 
 // List godoc
 // @Summary List of songs
-// @Description Gets a list of songs with filtering and pagination
+// @Description Gets a list of songs with filtering and pagination. Pass next_cursor or prev_cursor of a response as cursor to page by keyset instead of page number; page is ignored then. with_total=false skips counting the matching songs. When a name filter finds nothing on the first page, did_you_mean proposes close known names
 // @Tags songs
 // @Produce json
+// @Param artist_id query int false "Artist ID"
 // @Param group_name query string false "Group name"
 // @Param song_name query string false "Song name"
+// @Param release_date query string false "Release date (YYYY-MM-DD)"
+// @Param text query string false "Text fragment"
+// @Param link query string false "Link fragment"
+// @Param name_match query string false "How group_name and song_name match, ignoring case; fuzzy tolerates typos" Enums(contains, prefix, exact, fuzzy) default(contains)
+// @Param min_similarity query number false "Trigram similarity, above 0 and at most 1, that a fuzzy match needs" default(0.3)
+// @Param released_from query string false "Released on or after (YYYY-MM-DD)"
+// @Param released_to query string false "Released on or before (YYYY-MM-DD)"
+// @Param year query int false "Release year"
+// @Param decade query int false "Release decade, e.g. 1990"
+// @Param has_lyrics query bool false "Only songs with (true) or without (false) lyrics"
+// @Param has_link query bool false "Only songs with (true) or without (false) a link"
+// @Param created_from query string false "Created at or after (RFC 3339)"
+// @Param created_to query string false "Created at or before (RFC 3339)"
+// @Param updated_from query string false "Updated at or after (RFC 3339)"
+// @Param updated_to query string false "Updated at or before (RFC 3339)"
+// @Param min_average_rating query number false "Average rating of at least (0 to 5)"
+// @Param min_rating_count query int false "Rated at least this many times"
+// @Param min_favorite_count query int false "Favorited at least this many times"
+// @Param min_play_count query int false "Played at least this many times"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(10)
+// @Param sort query string false "Comma-separated sort keys, each optionally prefixed with - for descending order. Allowed: group_name, song_name, release_date, created_at, updated_at, average_rating, rating_count, favorite_count, play_count, relevance (ranks against text), similarity (ranks fuzzy name matches, the default with name_match=fuzzy). Ties are broken by id" example(group_name,-release_date)
+// @Param cursor query string false "Cursor from next_cursor or prev_cursor of an earlier page, valid only with the same sort"
+// @Param with_total query bool false "Count the matching songs" default(true)
 // @Success 200 {object} dto.SongListResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -246,13 +415,18 @@ func (h *SongHandler) List(c *gin.Context) {
 
 	songs, err := h.useCase.List(ctx, &req)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCursor) || errors.Is(err, usecase.ErrInvalidFilter) ||
+			errors.Is(err, repository.ErrInvalidSort) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
 		h.logger.Error(ctx, "Failed to retrieve song list", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	h.logger.Info(ctx, "Song list successfully retrieved", 
-		zap.Int("total", songs.Total),
+		zap.Int("retrieved", len(songs.Songs)),
 		zap.Int("page", songs.Page),
 		zap.Int("page_size", songs.PageSize))
 
@@ -260,12 +434,16 @@ func (h *SongHandler) List(c *gin.Context) {
 }
 
 // GetSongText godoc
-// @Summary Get song text with pagination by verses
-// @Description Returns the song text, split into verses with pagination
+// @Summary Get song text with pagination by sections
+// @Description Returns the song lyrics split into verse, chorus, bridge, intro and outro sections with pagination. Repeated sections are returned as markers unless expand is set
 // @Tags songs
 // @Accept json
 // @Produce json
 // @Param id path int true "Song ID"
+// @Param type query string false "Section type" Enums(verse, chorus, bridge, intro, outro)
+// @Param expand query bool false "Fill repeated sections with their lines" default(false)
+// @Param lang query string false "BCP-47 tag of a translation to page instead of the original"
+// @Param side_by_side query bool false "Pair original and translated sections, requires lang" default(false)
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(10)
 // @Success 200 {object} dto.SongTextResponse
@@ -303,6 +481,14 @@ func (h *SongHandler) GetSongText(c *gin.Context) {
 			c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
 			return
 		}
+		if errors.Is(err, repository.ErrTranslationNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "translation not found"})
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidLanguage) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
 		h.logger.Error(ctx, "Failed to retrieve song text", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
@@ -315,3 +501,46 @@ func (h *SongHandler) GetSongText(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// Search godoc
+// @Summary Full-text lyrics search
+// @Description Searches lyrics, song and group names ordered by relevance. Use "double quotes" for phrases and a trailing * for prefix matches
+// @Tags songs
+// @Produce json
+// @Param q query string true "Search query"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.SongSearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/search [get]
+func (h *SongHandler) Search(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.SongSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Debug(ctx, "Search parameters received", zap.Any("request", req))
+
+	response, err := h.useCase.Search(ctx, &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidSearchQuery) {
+			h.logger.Warn(ctx, "Invalid search query", zap.String("q", req.Query))
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "search query must contain at least one word"})
+			return
+		}
+		h.logger.Error(ctx, "Failed to search songs", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info(ctx, "Song search successfully completed",
+		zap.Int("total", response.Total),
+		zap.Int("page", response.Page))
+
+	c.JSON(http.StatusOK, response)
+}
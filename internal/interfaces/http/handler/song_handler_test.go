@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"song-library/internal/application/usecase"
+	"song-library/pkg/logger"
+)
+
+func TestListRejectsInvalidCursors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Cursors are checked before the repository is queried, so none is needed.
+	h := NewSongHandler(*usecase.NewSongUseCase(nil, nil, nil), logger.New("error"))
+	router := gin.New()
+	router.GET("/songs", h.List)
+
+	cursors := map[string]string{
+		"garbage":          "%%%",
+		"not JSON":         base64.RawURLEncoding.EncodeToString([]byte("id=1")),
+		"missing id":       base64.RawURLEncoding.EncodeToString([]byte(`{"keys":["a"]}`)),
+		"other sort order": base64.RawURLEncoding.EncodeToString([]byte(`{"id":1,"sort":"-song_name","keys":["a"]}`)),
+	}
+
+	for name, cursor := range cursors {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/songs?sort=song_name&cursor="+url.QueryEscape(cursor), nil)
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("GET /songs with a %s cursor = %d, want %d: %s", name, w.Code, http.StatusBadRequest, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+)
+
+// Suggest godoc
+// @Summary Autocomplete group and song names
+// @Description Returns up to limit distinct group names and song names starting with q, ignoring case and accents. Meant for type-ahead search: it answers from indexes only and gives up with 503 rather than answer late
+// @Tags songs
+// @Produce json
+// @Param q query string true "Prefix to complete"
+// @Param type query string false "Which names to complete" Enums(all, group, song) default(all)
+// @Param limit query int false "Maximum names per type" default(5) minimum(1) maximum(20)
+// @Success 200 {object} dto.SuggestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/suggest [get]
+func (h *SongHandler) Suggest(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.SuggestRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response, err := h.useCase.Suggest(ctx, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidFilter):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, usecase.ErrSuggestTimeout):
+			h.logger.Warn(ctx, "Name suggestions exceeded their latency budget", zap.String("q", req.Query))
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
+		default:
+			h.logger.Error(ctx, "Failed to suggest names", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	h.logger.Debug(ctx, "Names suggested",
+		zap.String("q", req.Query),
+		zap.Int("groups", len(response.Groups)),
+		zap.Int("songs", len(response.Songs)))
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+type TranslationHandler struct {
+	useCase usecase.TranslationUseCase
+	logger  *logger.Logger
+}
+
+func NewTranslationHandler(useCase usecase.TranslationUseCase, logger *logger.Logger) *TranslationHandler {
+	return &TranslationHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Create godoc
+// @Summary Add a lyrics translation
+// @Description Stores the song lyrics in another language identified by a BCP-47 tag
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Param request body dto.CreateTranslationRequest true "Translation data"
+// @Success 201 {object} dto.TranslationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/translations [post]
+func (h *TranslationHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	songID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	translation, err := h.useCase.Create(ctx, songID, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to create translation")
+		return
+	}
+
+	h.logger.Info(ctx, "Translation successfully created",
+		zap.Int64("song_id", songID),
+		zap.String("language", translation.Language))
+	c.JSON(http.StatusCreated, translation)
+}
+
+// Update godoc
+// @Summary Update a lyrics translation
+// @Description Replaces the text of an existing translation
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Param lang path string true "BCP-47 language tag"
+// @Param request body dto.UpdateTranslationRequest true "Translation data"
+// @Success 200 {object} dto.TranslationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/translations/{lang} [put]
+func (h *TranslationHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	songID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	translation, err := h.useCase.Update(ctx, songID, c.Param("lang"), &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update translation")
+		return
+	}
+
+	h.logger.Info(ctx, "Translation successfully updated",
+		zap.Int64("song_id", songID),
+		zap.String("language", translation.Language))
+	c.JSON(http.StatusOK, translation)
+}
+
+// Delete godoc
+// @Summary Delete a lyrics translation
+// @Description Removes the translation of the song into the given language
+// @Tags translations
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Param lang path string true "BCP-47 language tag"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/translations/{lang} [delete]
+func (h *TranslationHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	songID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(ctx, songID, c.Param("lang")); err != nil {
+		h.writeError(c, err, "Failed to delete translation")
+		return
+	}
+
+	h.logger.Info(ctx, "Translation successfully deleted",
+		zap.Int64("song_id", songID),
+		zap.String("language", c.Param("lang")))
+	c.Status(http.StatusNoContent)
+}
+
+// Get godoc
+// @Summary Get a lyrics translation
+// @Description Returns the translation of the song into the given language
+// @Tags translations
+// @Produce json
+// @Param id path int true "Song ID"
+// @Param lang path string true "BCP-47 language tag"
+// @Success 200 {object} dto.TranslationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/translations/{lang} [get]
+func (h *TranslationHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	songID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	translation, err := h.useCase.Get(ctx, songID, c.Param("lang"))
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve translation")
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+// List godoc
+// @Summary List lyrics translations
+// @Description Returns all translations of the song ordered by language
+// @Tags translations
+// @Produce json
+// @Param id path int true "Song ID"
+// @Success 200 {object} dto.TranslationListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/translations [get]
+func (h *TranslationHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	songID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	translations, err := h.useCase.List(ctx, songID)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve translations")
+		return
+	}
+
+	c.JSON(http.StatusOK, translations)
+}
+
+func (h *TranslationHandler) parseID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Failed to parse ID", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *TranslationHandler) writeError(c *gin.Context, err error, message string) {
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidLanguage):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, repository.ErrSongNotFound):
+		h.logger.Warn(ctx, "Song not found", zap.Error(err))
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found"})
+	case errors.Is(err, repository.ErrTranslationNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "translation not found"})
+	case errors.Is(err, repository.ErrTranslationAlreadyExists):
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "song already has a translation into this language"})
+	default:
+		h.logger.Error(ctx, message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/domain/repository"
+)
+
+// ListTrash godoc
+// @Summary List deleted songs
+// @Description Lists the songs in the trash, most recently deleted first. Deleted songs are hidden everywhere else and are purged for good once the retention period (TRASH_RETENTION) has passed
+// @Tags trash
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.TrashListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/trash [get]
+func (h *SongHandler) ListTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.TrashListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response, err := h.useCase.Trash(ctx, &req)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to list deleted songs", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	h.logger.Info(ctx, "Deleted songs listed", zap.Int("total", response.Total))
+	c.JSON(http.StatusOK, response)
+}
+
+// Restore godoc
+// @Summary Restore a deleted song
+// @Description Takes a song out of the trash with its lyrics, translations, revisions and album tracks. Fails with 409 when a song with the same group and title has been created since
+// @Tags trash
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Song ID"
+// @Success 200 {object} dto.SongResponse
+// @Header 200 {string} ETag "Version of the song"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/songs/{id}/restore [post]
+func (h *SongHandler) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseSongID(c)
+	if !ok {
+		return
+	}
+
+	song, err := h.useCase.Restore(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSongNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "song not found in trash"})
+		case errors.Is(err, repository.ErrSongAlreadyExists):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: "another song with this group and title already exists"})
+		default:
+			h.logger.Error(ctx, "Failed to restore song", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	h.logger.Info(ctx, "Song successfully restored", zap.Int64("id", id))
+	c.Header("ETag", songETag(song))
+	c.JSON(http.StatusOK, song)
+}
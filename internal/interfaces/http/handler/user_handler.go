@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/application/dto"
+	"song-library/internal/application/usecase"
+	"song-library/internal/domain/repository"
+	"song-library/pkg/logger"
+)
+
+type UserHandler struct {
+	useCase usecase.UserUseCase
+	logger  *logger.Logger
+}
+
+func NewUserHandler(useCase usecase.UserUseCase, logger *logger.Logger) *UserHandler {
+	return &UserHandler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// List godoc
+// @Summary List users
+// @Description Gets a list of users with filtering and pagination
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param username query string false "Part of the username"
+// @Param role query string false "Role" Enums(reader, editor, admin)
+// @Param disabled query bool false "Only disabled (true) or active (false) users"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.UserListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users [get]
+func (h *UserHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.UserListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind query parameters", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	users, err := h.useCase.List(ctx, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve user list")
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// UpdateRole godoc
+// @Summary Change the role of a user
+// @Description Sets the user's role. It applies from the user's next login or token refresh
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.UpdateUserRoleRequest true "New role"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{id}/role [put]
+func (h *UserHandler) UpdateRole(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(ctx, "Failed to bind JSON", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	user, err := h.useCase.UpdateRole(ctx, id, &req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update user role")
+		return
+	}
+
+	h.logger.Info(ctx, "User role successfully updated",
+		zap.Int64("id", id),
+		zap.String("role", user.Role))
+	c.JSON(http.StatusOK, user)
+}
+
+// Disable godoc
+// @Summary Disable a user
+// @Description Stops the user from logging in and revokes all of their refresh tokens. Access tokens already issued stay valid until they expire
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{id}/disable [post]
+func (h *UserHandler) Disable(c *gin.Context) {
+	h.setDisabled(c, true)
+}
+
+// Enable godoc
+// @Summary Enable a user
+// @Description Lets a disabled user log in again
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{id}/enable [post]
+func (h *UserHandler) Enable(c *gin.Context) {
+	h.setDisabled(c, false)
+}
+
+func (h *UserHandler) setDisabled(c *gin.Context, disabled bool) {
+	ctx := c.Request.Context()
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.useCase.SetDisabled(ctx, id, disabled)
+	if err != nil {
+		h.writeError(c, err, "Failed to update user")
+		return
+	}
+
+	h.logger.Info(ctx, "User successfully updated",
+		zap.Int64("id", id),
+		zap.Bool("disabled", disabled))
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) parseID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Failed to parse ID", zap.Error(err))
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *UserHandler) writeError(c *gin.Context, err error, message string) {
+	ctx := c.Request.Context()
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidUsername):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: usecase.ErrInvalidUsername.Error()})
+	case errors.Is(err, usecase.ErrPasswordTooLong):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: usecase.ErrPasswordTooLong.Error()})
+	case errors.Is(err, usecase.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: usecase.ErrInvalidCredentials.Error()})
+	case errors.Is(err, repository.ErrRefreshTokenInvalid), errors.Is(err, repository.ErrRefreshTokenReused):
+		h.logger.Warn(ctx, "Refresh token rejected", zap.Error(err))
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "invalid refresh token"})
+	case errors.Is(err, repository.ErrUserDisabled):
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "user is disabled"})
+	case errors.Is(err, repository.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "user not found"})
+	case errors.Is(err, repository.ErrUserAlreadyExists):
+		c.JSON(http.StatusConflict, ErrorResponse{Error: "username is already taken"})
+	case errors.Is(err, usecase.ErrTokenIssuerDisabled):
+		h.logger.Error(ctx, message, zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "user logins are not configured"})
+	default:
+		h.logger.Error(ctx, message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"song-library/internal/domain/entity"
+	"song-library/internal/infrastructure/auth"
+	"song-library/pkg/logger"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Authenticate identifies the caller from an "Authorization: Bearer" JWT or
+// an X-API-Key header and stores the principal in the request context.
+// Requests without credentials pass through anonymously and are stopped by
+// RequireRole where needed; wrong credentials are rejected straight away so
+// that a typo does not silently turn into an anonymous request.
+func Authenticate(authenticator *auth.Authenticator, logger *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		var principal *entity.Principal
+		var err error
+		switch {
+		case c.GetHeader("Authorization") != "":
+			token, ok := bearerToken(c.GetHeader("Authorization"))
+			if !ok {
+				unauthorized(c, "authorization header must use the Bearer scheme")
+				return
+			}
+			principal, err = authenticator.AuthenticateToken(token)
+		case c.GetHeader(apiKeyHeader) != "":
+			principal, err = authenticator.AuthenticateAPIKey(c.GetHeader(apiKeyHeader))
+		default:
+			c.Next()
+			return
+		}
+		if err != nil {
+			logger.Warn(ctx, "Authentication failed",
+				zap.String("path", c.FullPath()),
+				zap.Error(err))
+			unauthorized(c, "invalid credentials")
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithPrincipal(ctx, principal))
+		c.Next()
+	}
+}
+
+// RequireRole lets the request through only if the caller's role includes
+// role: anonymous callers get 401, callers with a lesser role 403.
+func RequireRole(role entity.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := auth.PrincipalFromContext(c.Request.Context())
+		if principal == nil {
+			unauthorized(c, "authentication required")
+			return
+		}
+		if !principal.Role.Allows(role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, errorResponse{Error: "the " + string(role) + " role is required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func unauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="song-library"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse{Error: message})
+}
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_songs_enrichment_queue;
+
+ALTER TABLE songs
+    DROP CONSTRAINT IF EXISTS chk_songs_enrichment_status,
+    DROP COLUMN IF EXISTS next_enrichment_at,
+    DROP COLUMN IF EXISTS enrichment_error,
+    DROP COLUMN IF EXISTS enrichment_attempts,
+    DROP COLUMN IF EXISTS enrichment_status;
@@ -0,0 +1,12 @@
+ALTER TABLE songs
+    ADD COLUMN enrichment_status VARCHAR(16) NOT NULL DEFAULT 'done',
+    ADD COLUMN enrichment_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN enrichment_error TEXT,
+    ADD COLUMN next_enrichment_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE songs
+    ADD CONSTRAINT chk_songs_enrichment_status
+    CHECK (enrichment_status IN ('pending', 'done', 'failed'));
+
+CREATE INDEX idx_songs_enrichment_queue ON songs(next_enrichment_at)
+    WHERE enrichment_status IN ('pending', 'failed') AND next_enrichment_at IS NOT NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE songs
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(song_name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(group_name, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(text, '')), 'C')
+    ) STORED;
+
+CREATE INDEX idx_songs_search_vector ON songs USING GIN (search_vector);
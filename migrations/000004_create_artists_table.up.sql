@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS artists (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    normalized_name VARCHAR(255) GENERATED ALWAYS AS (lower(btrim(regexp_replace(name, '\s+', ' ', 'g')))) STORED,
+    country VARCHAR(64),
+    formed_year INTEGER,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_artists_normalized_name ON artists(normalized_name);
+
+INSERT INTO artists (name)
+SELECT DISTINCT ON (normalized) name
+FROM (
+    SELECT btrim(regexp_replace(group_name, '\s+', ' ', 'g')) AS name,
+           lower(btrim(regexp_replace(group_name, '\s+', ' ', 'g'))) AS normalized,
+           COUNT(*) AS songs,
+           MIN(id) AS first_song_id
+    FROM songs
+    GROUP BY 1, 2
+) spellings
+ORDER BY normalized, songs DESC, first_song_id;
+
+ALTER TABLE songs ADD COLUMN artist_id INTEGER REFERENCES artists(id) ON DELETE RESTRICT;
+
+UPDATE songs s
+SET artist_id = a.id,
+    group_name = a.name
+FROM artists a
+WHERE a.normalized_name = lower(btrim(regexp_replace(s.group_name, '\s+', ' ', 'g')));
+
+ALTER TABLE songs ALTER COLUMN artist_id SET NOT NULL;
+
+CREATE INDEX idx_songs_artist_id ON songs(artist_id);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS albums (
+    id SERIAL PRIMARY KEY,
+    artist_id INTEGER NOT NULL REFERENCES artists(id) ON DELETE RESTRICT,
+    title VARCHAR(255) NOT NULL,
+    normalized_title VARCHAR(255) GENERATED ALWAYS AS (lower(btrim(regexp_replace(title, '\s+', ' ', 'g')))) STORED,
+    album_type VARCHAR(16) NOT NULL DEFAULT 'LP',
+    release_date DATE,
+    cover_link VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_albums_album_type CHECK (album_type IN ('LP', 'EP', 'single'))
+);
+
+CREATE UNIQUE INDEX idx_albums_artist_title ON albums(artist_id, normalized_title);
+
+CREATE TABLE IF NOT EXISTS album_tracks (
+    album_id INTEGER NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    disc_number INTEGER NOT NULL DEFAULT 1 CHECK (disc_number > 0),
+    track_number INTEGER NOT NULL CHECK (track_number > 0),
+    PRIMARY KEY (album_id, song_id),
+    CONSTRAINT uq_album_tracks_position UNIQUE (album_id, disc_number, track_number)
+);
+
+CREATE INDEX idx_album_tracks_song_id ON album_tracks(song_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS song_sections (
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    section_type VARCHAR(16) NOT NULL,
+    ordinal INTEGER NOT NULL,
+    lines TEXT[] NOT NULL DEFAULT '{}',
+    is_repeat BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (song_id, position),
+    CONSTRAINT chk_song_sections_type CHECK (section_type IN ('verse', 'chorus', 'bridge', 'intro', 'outro'))
+);
+
+CREATE INDEX idx_song_sections_type ON song_sections(song_id, section_type);
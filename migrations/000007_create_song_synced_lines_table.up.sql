@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS song_synced_lines (
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    start_ms INTEGER NOT NULL,
+    text TEXT NOT NULL DEFAULT '',
+    word_starts_ms INTEGER[] NOT NULL DEFAULT '{}',
+    words TEXT[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (song_id, position),
+    CONSTRAINT chk_song_synced_lines_start CHECK (start_ms >= 0),
+    CONSTRAINT chk_song_synced_lines_words CHECK (cardinality(word_starts_ms) = cardinality(words))
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS song_translations (
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    language VARCHAR(35) NOT NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (song_id, language)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS song_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    group_name VARCHAR(255) NOT NULL,
+    song_name VARCHAR(255) NOT NULL,
+    release_date DATE,
+    text TEXT,
+    link VARCHAR(255),
+    editor VARCHAR(255) NOT NULL,
+    restored_from INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT uq_song_revisions_revision UNIQUE (song_id, revision)
+);
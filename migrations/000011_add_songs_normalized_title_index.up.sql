@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_songs_artist_normalized_title
+    ON songs (artist_id, lower(btrim(regexp_replace(song_name, '\s+', ' ', 'g'))));
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_artists_name_trgm;
+DROP INDEX IF EXISTS idx_songs_song_name_trgm;
+DROP INDEX IF EXISTS idx_songs_group_name_trgm;
+
+DROP EXTENSION IF EXISTS pg_trgm;
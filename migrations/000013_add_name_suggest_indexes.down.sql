@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_songs_song_name_suggest;
+DROP INDEX IF EXISTS idx_artists_name_suggest;
+
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
+
+DROP EXTENSION IF EXISTS unaccent;
@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE because its dictionary could change, so indexes
+-- need a wrapper that names the dictionary and promises to be IMMUTABLE.
+CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+CREATE INDEX IF NOT EXISTS idx_artists_name_suggest ON artists (lower(immutable_unaccent(name)) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_songs_song_name_suggest ON songs (lower(immutable_unaccent(song_name)) text_pattern_ops);
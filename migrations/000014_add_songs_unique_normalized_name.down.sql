@@ -0,0 +1,70 @@
+DROP INDEX IF EXISTS idx_songs_artist_normalized_name;
+
+CREATE INDEX IF NOT EXISTS idx_songs_artist_normalized_title
+    ON songs (artist_id, lower(btrim(regexp_replace(song_name, '\s+', ' ', 'g'))));
+
+ALTER TABLE songs DROP COLUMN IF EXISTS normalized_name;
+
+-- Undo the merge from the archive the up migration kept. Keepers edited since
+-- keep their current fields, and rows moved to a keeper stay there if they
+-- changed since; the duplicates get their own copy back either way.
+UPDATE songs k
+SET release_date = a.release_date,
+    text = a.text,
+    link = a.link,
+    updated_at = a.updated_at,
+    version = a.version
+FROM song_keepers_archive a
+WHERE k.id = a.song_id AND k.version = a.version + 1;
+
+DELETE FROM song_sections r USING song_duplicate_rows_archive a
+WHERE a.table_name = 'song_sections' AND a.moved AND to_jsonb(r) = a.data;
+DELETE FROM song_translations r USING song_duplicate_rows_archive a
+WHERE a.table_name = 'song_translations' AND a.moved AND to_jsonb(r) = a.data;
+DELETE FROM song_synced_lines r USING song_duplicate_rows_archive a
+WHERE a.table_name = 'song_synced_lines' AND a.moved AND to_jsonb(r) = a.data;
+DELETE FROM album_tracks r USING song_duplicate_rows_archive a
+WHERE a.table_name = 'album_tracks' AND a.moved AND to_jsonb(r) = a.data;
+
+INSERT INTO songs (id, artist_id, group_name, song_name, release_date, text, link, created_at, updated_at,
+    enrichment_status, enrichment_attempts, enrichment_error, next_enrichment_at, version)
+SELECT s.id, s.artist_id, s.group_name, s.song_name, s.release_date, s.text, s.link, s.created_at, s.updated_at,
+    s.enrichment_status, s.enrichment_attempts, s.enrichment_error, s.next_enrichment_at, s.version
+FROM song_duplicates_archive a
+CROSS JOIN LATERAL jsonb_populate_record(NULL::songs, a.song) s
+WHERE EXISTS (SELECT 1 FROM artists WHERE id = s.artist_id)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO song_sections
+SELECT (jsonb_populate_record(NULL::song_sections, a.data || jsonb_build_object('song_id', a.song_id))).*
+FROM song_duplicate_rows_archive a
+WHERE a.table_name = 'song_sections' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO song_translations
+SELECT (jsonb_populate_record(NULL::song_translations, a.data || jsonb_build_object('song_id', a.song_id))).*
+FROM song_duplicate_rows_archive a
+WHERE a.table_name = 'song_translations' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO song_synced_lines
+SELECT (jsonb_populate_record(NULL::song_synced_lines, a.data || jsonb_build_object('song_id', a.song_id))).*
+FROM song_duplicate_rows_archive a
+WHERE a.table_name = 'song_synced_lines' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO album_tracks
+SELECT (jsonb_populate_record(NULL::album_tracks, a.data || jsonb_build_object('song_id', a.song_id))).*
+FROM song_duplicate_rows_archive a
+WHERE a.table_name = 'album_tracks' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO song_revisions
+SELECT (jsonb_populate_record(NULL::song_revisions, a.data)).*
+FROM song_duplicate_rows_archive a
+WHERE a.table_name = 'song_revisions' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS song_duplicate_rows_archive;
+DROP TABLE IF EXISTS song_keepers_archive;
+DROP TABLE IF EXISTS song_duplicates_archive;
@@ -0,0 +1,169 @@
+ALTER TABLE songs
+    ADD COLUMN normalized_name VARCHAR(255) GENERATED ALWAYS AS (lower(btrim(regexp_replace(song_name, '\s+', ' ', 'g')))) STORED;
+
+-- Fold songs that already share an artist and normalised title into the
+-- oldest one before the key becomes unique: empty fields are filled from the
+-- duplicates in id order, and translations, synced lyrics and album tracks
+-- the oldest song lacks are moved over.
+--
+-- Nothing is lost: the duplicates, every row that belonged to them (moved or
+-- not, revisions included) and the keepers' fields before the merge are
+-- archived first, and the down migration restores them from there.
+CREATE TEMPORARY TABLE song_duplicates AS
+SELECT id AS duplicate_id, keeper_id
+FROM (
+    SELECT id, first_value(id) OVER (PARTITION BY artist_id, normalized_name ORDER BY id) AS keeper_id
+    FROM songs
+) ranked
+WHERE id <> keeper_id;
+
+CREATE TABLE IF NOT EXISTS song_duplicates_archive (
+    song_id INTEGER PRIMARY KEY,
+    keeper_id INTEGER NOT NULL,
+    song JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS song_keepers_archive (
+    song_id INTEGER PRIMARY KEY,
+    release_date DATE,
+    text TEXT,
+    link VARCHAR(255),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    version INTEGER NOT NULL
+);
+
+-- moved marks rows that now belong to the keeper; the others were removed
+-- with their song.
+CREATE TABLE IF NOT EXISTS song_duplicate_rows_archive (
+    table_name VARCHAR(32) NOT NULL,
+    song_id INTEGER NOT NULL,
+    moved BOOLEAN NOT NULL,
+    data JSONB NOT NULL
+);
+
+INSERT INTO song_duplicates_archive (song_id, keeper_id, song)
+SELECT s.id, d.keeper_id, to_jsonb(s) - 'normalized_name' - 'search_vector'
+FROM songs s
+JOIN song_duplicates d ON d.duplicate_id = s.id;
+
+INSERT INTO song_keepers_archive (song_id, release_date, text, link, updated_at, version)
+SELECT s.id, s.release_date, s.text, s.link, s.updated_at, s.version
+FROM songs s
+WHERE s.id IN (SELECT keeper_id FROM song_duplicates);
+
+-- A keeper without text takes the text of the first duplicate that has one,
+-- so it takes that duplicate's sections as well.
+WITH moved AS (
+    UPDATE song_sections ss
+    SET song_id = d.keeper_id
+    FROM song_duplicates d
+    WHERE ss.song_id = d.duplicate_id
+      AND d.duplicate_id = (
+          SELECT MIN(d2.duplicate_id)
+          FROM song_duplicates d2
+          JOIN songs s2 ON s2.id = d2.duplicate_id
+          WHERE d2.keeper_id = d.keeper_id AND s2.text <> ''
+      )
+      AND (SELECT COALESCE(text, '') FROM songs WHERE id = d.keeper_id) = ''
+    RETURNING ss.*, d.duplicate_id
+)
+INSERT INTO song_duplicate_rows_archive (table_name, song_id, moved, data)
+SELECT 'song_sections', duplicate_id, TRUE, to_jsonb(moved) - 'duplicate_id'
+FROM moved;
+
+UPDATE songs k
+SET release_date = COALESCE(k.release_date, f.release_date),
+    text = COALESCE(NULLIF(k.text, ''), f.text),
+    link = COALESCE(NULLIF(k.link, ''), f.link),
+    updated_at = NOW(),
+    version = k.version + 1
+FROM (
+    SELECT d.keeper_id,
+           (array_agg(s.release_date ORDER BY s.id) FILTER (WHERE s.release_date IS NOT NULL))[1] AS release_date,
+           (array_agg(s.text ORDER BY s.id) FILTER (WHERE s.text <> ''))[1] AS text,
+           (array_agg(s.link ORDER BY s.id) FILTER (WHERE s.link <> ''))[1] AS link
+    FROM song_duplicates d
+    JOIN songs s ON s.id = d.duplicate_id
+    GROUP BY d.keeper_id
+) f
+WHERE k.id = f.keeper_id;
+
+WITH moved AS (
+    UPDATE song_translations t
+    SET song_id = d.keeper_id
+    FROM song_duplicates d
+    WHERE t.song_id = d.duplicate_id
+      AND d.duplicate_id = (
+          SELECT MIN(d2.duplicate_id)
+          FROM song_duplicates d2
+          JOIN song_translations t2 ON t2.song_id = d2.duplicate_id
+          WHERE d2.keeper_id = d.keeper_id AND t2.language = t.language
+      )
+      AND NOT EXISTS (SELECT 1 FROM song_translations k WHERE k.song_id = d.keeper_id AND k.language = t.language)
+    RETURNING t.*, d.duplicate_id
+)
+INSERT INTO song_duplicate_rows_archive (table_name, song_id, moved, data)
+SELECT 'song_translations', duplicate_id, TRUE, to_jsonb(moved) - 'duplicate_id'
+FROM moved;
+
+WITH moved AS (
+    UPDATE song_synced_lines l
+    SET song_id = d.keeper_id
+    FROM song_duplicates d
+    WHERE l.song_id = d.duplicate_id
+      AND d.duplicate_id = (
+          SELECT MIN(d2.duplicate_id)
+          FROM song_duplicates d2
+          WHERE d2.keeper_id = d.keeper_id
+            AND EXISTS (SELECT 1 FROM song_synced_lines l2 WHERE l2.song_id = d2.duplicate_id)
+      )
+      AND NOT EXISTS (SELECT 1 FROM song_synced_lines k WHERE k.song_id = d.keeper_id)
+    RETURNING l.*, d.duplicate_id
+)
+INSERT INTO song_duplicate_rows_archive (table_name, song_id, moved, data)
+SELECT 'song_synced_lines', duplicate_id, TRUE, to_jsonb(moved) - 'duplicate_id'
+FROM moved;
+
+WITH moved AS (
+    UPDATE album_tracks t
+    SET song_id = d.keeper_id
+    FROM song_duplicates d
+    WHERE t.song_id = d.duplicate_id
+      AND d.duplicate_id = (
+          SELECT MIN(d2.duplicate_id)
+          FROM song_duplicates d2
+          JOIN album_tracks t2 ON t2.song_id = d2.duplicate_id
+          WHERE d2.keeper_id = d.keeper_id AND t2.album_id = t.album_id
+      )
+      AND NOT EXISTS (SELECT 1 FROM album_tracks k WHERE k.song_id = d.keeper_id AND k.album_id = t.album_id)
+    RETURNING t.*, d.duplicate_id
+)
+INSERT INTO song_duplicate_rows_archive (table_name, song_id, moved, data)
+SELECT 'album_tracks', duplicate_id, TRUE, to_jsonb(moved) - 'duplicate_id'
+FROM moved;
+
+INSERT INTO song_duplicate_rows_archive (table_name, song_id, moved, data)
+SELECT 'song_sections', song_id, FALSE, to_jsonb(r) FROM song_sections r
+WHERE song_id IN (SELECT duplicate_id FROM song_duplicates)
+UNION ALL
+SELECT 'song_translations', song_id, FALSE, to_jsonb(r) FROM song_translations r
+WHERE song_id IN (SELECT duplicate_id FROM song_duplicates)
+UNION ALL
+SELECT 'song_synced_lines', song_id, FALSE, to_jsonb(r) FROM song_synced_lines r
+WHERE song_id IN (SELECT duplicate_id FROM song_duplicates)
+UNION ALL
+SELECT 'album_tracks', song_id, FALSE, to_jsonb(r) FROM album_tracks r
+WHERE song_id IN (SELECT duplicate_id FROM song_duplicates)
+UNION ALL
+SELECT 'song_revisions', song_id, FALSE, to_jsonb(r) FROM song_revisions r
+WHERE song_id IN (SELECT duplicate_id FROM song_duplicates);
+
+DELETE FROM songs s
+USING song_duplicates d
+WHERE s.id = d.duplicate_id;
+
+DROP TABLE song_duplicates;
+
+DROP INDEX IF EXISTS idx_songs_artist_normalized_title;
+
+CREATE UNIQUE INDEX idx_songs_artist_normalized_name ON songs(artist_id, normalized_name);
@@ -0,0 +1,61 @@
+-- Trashed songs are taken out of the trash rather than dropped. Those whose
+-- title is now held by a live song, or by an older trashed one, would break
+-- the unique key again: they are archived with every row that belongs to
+-- them before they are removed. Every trashed song is remembered so that the
+-- up migration can put it back in the trash.
+CREATE TABLE IF NOT EXISTS song_trash_archive (
+    song_id INTEGER PRIMARY KEY,
+    deleted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- song is set for the songs that were removed.
+    song JSONB
+);
+
+CREATE TABLE IF NOT EXISTS song_trash_rows_archive (
+    table_name VARCHAR(32) NOT NULL,
+    song_id INTEGER NOT NULL,
+    data JSONB NOT NULL
+);
+
+CREATE TEMPORARY TABLE song_trash_conflicts AS
+SELECT id
+FROM (
+    SELECT id, deleted_at,
+           row_number() OVER (PARTITION BY artist_id, normalized_name ORDER BY deleted_at IS NOT NULL, id) AS n
+    FROM songs
+) ranked
+WHERE deleted_at IS NOT NULL AND n > 1;
+
+INSERT INTO song_trash_archive (song_id, deleted_at, song)
+SELECT s.id, s.deleted_at,
+       CASE WHEN c.id IS NOT NULL THEN to_jsonb(s) - 'normalized_name' - 'search_vector' - 'deleted_at' END
+FROM songs s
+LEFT JOIN song_trash_conflicts c ON c.id = s.id
+WHERE s.deleted_at IS NOT NULL
+ON CONFLICT (song_id) DO NOTHING;
+
+INSERT INTO song_trash_rows_archive (table_name, song_id, data)
+SELECT 'song_sections', song_id, to_jsonb(r) FROM song_sections r
+WHERE song_id IN (SELECT id FROM song_trash_conflicts)
+UNION ALL
+SELECT 'song_translations', song_id, to_jsonb(r) FROM song_translations r
+WHERE song_id IN (SELECT id FROM song_trash_conflicts)
+UNION ALL
+SELECT 'song_synced_lines', song_id, to_jsonb(r) FROM song_synced_lines r
+WHERE song_id IN (SELECT id FROM song_trash_conflicts)
+UNION ALL
+SELECT 'album_tracks', song_id, to_jsonb(r) FROM album_tracks r
+WHERE song_id IN (SELECT id FROM song_trash_conflicts)
+UNION ALL
+SELECT 'song_revisions', song_id, to_jsonb(r) FROM song_revisions r
+WHERE song_id IN (SELECT id FROM song_trash_conflicts);
+
+DELETE FROM songs WHERE id IN (SELECT id FROM song_trash_conflicts);
+
+DROP TABLE song_trash_conflicts;
+
+DROP INDEX IF EXISTS idx_songs_deleted_at;
+
+DROP INDEX IF EXISTS idx_songs_artist_normalized_name;
+CREATE UNIQUE INDEX idx_songs_artist_normalized_name ON songs(artist_id, normalized_name);
+
+ALTER TABLE songs DROP COLUMN IF EXISTS deleted_at;
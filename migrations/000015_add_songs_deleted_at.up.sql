@@ -0,0 +1,68 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Songs in the trash no longer claim their title, so the same song can be
+-- created again; restoring one of them then conflicts.
+DROP INDEX IF EXISTS idx_songs_artist_normalized_name;
+CREATE UNIQUE INDEX idx_songs_artist_normalized_name ON songs(artist_id, normalized_name) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_songs_deleted_at ON songs(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Put back in the trash what an earlier down migration took out of it.
+CREATE TABLE IF NOT EXISTS song_trash_archive (
+    song_id INTEGER PRIMARY KEY,
+    deleted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    song JSONB
+);
+
+CREATE TABLE IF NOT EXISTS song_trash_rows_archive (
+    table_name VARCHAR(32) NOT NULL,
+    song_id INTEGER NOT NULL,
+    data JSONB NOT NULL
+);
+
+UPDATE songs s
+SET deleted_at = a.deleted_at
+FROM song_trash_archive a
+WHERE s.id = a.song_id AND a.song IS NULL;
+
+INSERT INTO songs (id, artist_id, group_name, song_name, release_date, text, link, created_at, updated_at,
+    enrichment_status, enrichment_attempts, enrichment_error, next_enrichment_at, version, deleted_at)
+SELECT s.id, s.artist_id, s.group_name, s.song_name, s.release_date, s.text, s.link, s.created_at, s.updated_at,
+    s.enrichment_status, s.enrichment_attempts, s.enrichment_error, s.next_enrichment_at, s.version, a.deleted_at
+FROM song_trash_archive a
+CROSS JOIN LATERAL jsonb_populate_record(NULL::songs, a.song) s
+WHERE a.song IS NOT NULL AND EXISTS (SELECT 1 FROM artists WHERE id = s.artist_id)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO song_sections
+SELECT (jsonb_populate_record(NULL::song_sections, a.data)).*
+FROM song_trash_rows_archive a
+WHERE a.table_name = 'song_sections' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO song_translations
+SELECT (jsonb_populate_record(NULL::song_translations, a.data)).*
+FROM song_trash_rows_archive a
+WHERE a.table_name = 'song_translations' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO song_synced_lines
+SELECT (jsonb_populate_record(NULL::song_synced_lines, a.data)).*
+FROM song_trash_rows_archive a
+WHERE a.table_name = 'song_synced_lines' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO album_tracks
+SELECT (jsonb_populate_record(NULL::album_tracks, a.data)).*
+FROM song_trash_rows_archive a
+WHERE a.table_name = 'album_tracks' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO song_revisions
+SELECT (jsonb_populate_record(NULL::song_revisions, a.data)).*
+FROM song_trash_rows_archive a
+WHERE a.table_name = 'song_revisions' AND a.song_id IN (SELECT id FROM songs)
+ON CONFLICT DO NOTHING;
+
+DROP TABLE song_trash_rows_archive;
+DROP TABLE song_trash_archive;
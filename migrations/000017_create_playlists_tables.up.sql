@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS playlists (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    visibility VARCHAR(16) NOT NULL DEFAULT 'private' CHECK (visibility IN ('public', 'private')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_playlists_user_id ON playlists(user_id);
+
+-- Items are ordered by a sparse rank so that an item can be placed between
+-- two others without renumbering the rest. The same song may appear more than
+-- once; items of purged songs go with them.
+CREATE TABLE IF NOT EXISTS playlist_items (
+    id BIGSERIAL PRIMARY KEY,
+    playlist_id INTEGER NOT NULL REFERENCES playlists(id) ON DELETE CASCADE,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    rank BIGINT NOT NULL,
+    added_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_playlist_items_playlist_rank ON playlist_items(playlist_id, rank);
+CREATE INDEX idx_playlist_items_song_id ON playlist_items(song_id);
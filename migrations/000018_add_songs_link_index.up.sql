@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_songs_link ON songs (link) WHERE link <> '';
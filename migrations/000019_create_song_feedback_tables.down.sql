@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS song_plays;
+DROP TABLE IF EXISTS song_ratings;
+DROP TABLE IF EXISTS song_favorites;
+
+DROP TABLE IF EXISTS song_stats;
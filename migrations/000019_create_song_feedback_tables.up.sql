@@ -0,0 +1,57 @@
+-- The aggregates live in song_stats, one row per song, so that lists can
+-- filter and sort by them without joining the event tables. They are kept up
+-- to date in the same transaction as the favorite, rating or play that
+-- changes them; keeping them off songs means that this never locks or
+-- rewrites the song row that edits and enrichment claims work on.
+CREATE TABLE IF NOT EXISTS song_stats (
+    song_id INTEGER PRIMARY KEY REFERENCES songs(id) ON DELETE CASCADE,
+    favorite_count INTEGER NOT NULL DEFAULT 0,
+    rating_count INTEGER NOT NULL DEFAULT 0,
+    rating_sum INTEGER NOT NULL DEFAULT 0,
+    average_rating DOUBLE PRECISION GENERATED ALWAYS AS
+        (COALESCE(rating_sum::double precision / NULLIF(rating_count, 0), 0)) STORED,
+    play_count BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO song_stats (song_id)
+SELECT id FROM songs
+ON CONFLICT DO NOTHING;
+
+-- Lists sort by the aggregates with the song id as the tie-breaker and
+-- filter by lower bounds on them.
+CREATE INDEX idx_song_stats_favorite_count ON song_stats(favorite_count, song_id);
+CREATE INDEX idx_song_stats_rating_count ON song_stats(rating_count, song_id);
+CREATE INDEX idx_song_stats_average_rating ON song_stats(average_rating, song_id);
+CREATE INDEX idx_song_stats_play_count ON song_stats(play_count, song_id);
+
+CREATE TABLE IF NOT EXISTS song_favorites (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, song_id)
+);
+
+CREATE INDEX idx_song_favorites_song_id ON song_favorites(song_id);
+
+CREATE TABLE IF NOT EXISTS song_ratings (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, song_id)
+);
+
+CREATE INDEX idx_song_ratings_song_id ON song_ratings(song_id);
+
+-- Plays by API keys have no user. Plays stay counted when their user is
+-- deleted.
+CREATE TABLE IF NOT EXISTS song_plays (
+    id BIGSERIAL PRIMARY KEY,
+    song_id INTEGER NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    played_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_song_plays_song_id ON song_plays(song_id);
+CREATE INDEX idx_song_plays_user_id ON song_plays(user_id);
@@ -0,0 +1,225 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("decoding %s: %v", data, err)
+	}
+	return doc
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "add member",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"add","path":"/b","value":2}]`,
+			want:  `{"a":1,"b":2}`,
+		},
+		{
+			name:  "add null value",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"add","path":"/b","value":null}]`,
+			want:  `{"a":1,"b":null}`,
+		},
+		{
+			name:  "replace with null",
+			doc:   `{"link":"https://example.com"}`,
+			patch: `[{"op":"replace","path":"/link","value":null}]`,
+			want:  `{"link":null}`,
+		},
+		{
+			name:  "test null value",
+			doc:   `{"a":null}`,
+			patch: `[{"op":"test","path":"/a","value":null},{"op":"add","path":"/b","value":true}]`,
+			want:  `{"a":null,"b":true}`,
+		},
+		{
+			name:    "missing value",
+			doc:     `{"a":1}`,
+			patch:   `[{"op":"replace","path":"/a"}]`,
+			wantErr: true,
+		},
+		{
+			name:  "remove member",
+			doc:   `{"a":1,"b":2}`,
+			patch: `[{"op":"remove","path":"/a"}]`,
+			want:  `{"b":2}`,
+		},
+		{
+			name:    "remove missing member",
+			doc:     `{"a":1}`,
+			patch:   `[{"op":"remove","path":"/b"}]`,
+			wantErr: true,
+		},
+		{
+			name:  "array insert and append",
+			doc:   `{"a":[1,3]}`,
+			patch: `[{"op":"add","path":"/a/1","value":2},{"op":"add","path":"/a/-","value":4}]`,
+			want:  `{"a":[1,2,3,4]}`,
+		},
+		{
+			name:  "array remove",
+			doc:   `{"a":[1,2,3]}`,
+			patch: `[{"op":"remove","path":"/a/0"}]`,
+			want:  `{"a":[2,3]}`,
+		},
+		{
+			name:    "array index with leading zero",
+			doc:     `{"a":[1,2]}`,
+			patch:   `[{"op":"remove","path":"/a/01"}]`,
+			wantErr: true,
+		},
+		{
+			name:  "move member",
+			doc:   `{"a":{"b":1}}`,
+			patch: `[{"op":"move","from":"/a/b","path":"/c"}]`,
+			want:  `{"a":{},"c":1}`,
+		},
+		{
+			name:    "move into itself",
+			doc:     `{"a":{"b":1}}`,
+			patch:   `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			wantErr: true,
+		},
+		{
+			name:  "copy is deep",
+			doc:   `{"a":{"b":1}}`,
+			patch: `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`,
+			want:  `{"a":{"b":1},"c":{"b":2}}`,
+		},
+		{
+			name:  "escaped pointer",
+			doc:   `{"a/b":1,"c~d":2}`,
+			patch: `[{"op":"remove","path":"/a~1b"},{"op":"remove","path":"/c~0d"}]`,
+			want:  `{}`,
+		},
+		{
+			name:    "failed test aborts the patch",
+			doc:     `{"a":1}`,
+			patch:   `[{"op":"test","path":"/a","value":2}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown op",
+			doc:     `{"a":1}`,
+			patch:   `[{"op":"rename","path":"/a"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "path without leading slash",
+			doc:     `{"a":1}`,
+			patch:   `[{"op":"remove","path":"a"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "patch is not an array",
+			doc:     `{"a":1}`,
+			patch:   `{"op":"remove","path":"/a"}`,
+			wantErr: true,
+		},
+		{
+			name:    "replacing the document with a scalar",
+			doc:     `{"a":1}`,
+			patch:   `[{"op":"replace","path":"","value":1}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply(decode(t, tt.doc), []byte(tt.patch))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPatch) {
+					t.Fatalf("Apply() error = %v, want ErrInvalidPatch", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("Apply() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "replace member",
+			doc:   `{"a":1,"b":2}`,
+			patch: `{"a":3}`,
+			want:  `{"a":3,"b":2}`,
+		},
+		{
+			name:  "null removes member",
+			doc:   `{"a":1,"b":2}`,
+			patch: `{"a":null}`,
+			want:  `{"b":2}`,
+		},
+		{
+			name:  "nested objects merge",
+			doc:   `{"a":{"b":1,"c":2}}`,
+			patch: `{"a":{"c":null,"d":3}}`,
+			want:  `{"a":{"b":1,"d":3}}`,
+		},
+		{
+			name:  "arrays are replaced",
+			doc:   `{"a":[1,2]}`,
+			patch: `{"a":[3]}`,
+			want:  `{"a":[3]}`,
+		},
+		{
+			name:    "patch is not an object",
+			doc:     `{"a":1}`,
+			patch:   `[1]`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed patch",
+			doc:     `{"a":1}`,
+			patch:   `{"a":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch(decode(t, tt.doc), []byte(tt.patch))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPatch) {
+					t.Fatalf("MergePatch() error = %v, want ErrInvalidPatch", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MergePatch() error = %v", err)
+			}
+			if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("MergePatch() = %v, want %v", got, want)
+			}
+		})
+	}
+}
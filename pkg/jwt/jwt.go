@@ -0,0 +1,226 @@
+// Package jwt verifies JSON Web Tokens (RFC 7519) signed with HS256 or RS256
+// and signs HS256 tokens. Only compact JWS tokens are supported; the algorithm
+// must match one of the configured keys, so a token cannot pick a weaker
+// algorithm than expected.
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Keys are the keys tokens may be signed with. At least one must be set.
+type Keys struct {
+	HMACSecret   []byte
+	RSAPublicKey *rsa.PublicKey
+}
+
+// RegisteredClaims are the claims the verifier checks. Audience accepts both
+// a single string and an array of strings.
+type RegisteredClaims struct {
+	Subject   string   `json:"sub,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+}
+
+// Audience is marshalled as a single string when it has one element.
+type Audience []string
+
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// Verifier checks signatures and the exp, nbf, iss and aud claims. Issuer
+// and Audience are only checked when set; Leeway absorbs clock skew.
+type Verifier struct {
+	keys     Keys
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+	now      func() time.Time
+}
+
+func NewVerifier(keys Keys) (*Verifier, error) {
+	if len(keys.HMACSecret) == 0 && keys.RSAPublicKey == nil {
+		return nil, errors.New("jwt: no verification key configured")
+	}
+	return &Verifier{keys: keys, now: time.Now}, nil
+}
+
+// Verify checks the token and decodes its payload into claims, which may be
+// any JSON-decodable value with the caller's private claims.
+func (v *Verifier) Verify(token string, claims interface{}) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: token must have three parts", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Typ string `json:"typ"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return fmt.Errorf("%w: bad header: %v", ErrInvalidToken, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("%w: bad signature encoding", ErrInvalidToken)
+	}
+	if err := v.verifySignature(header.Alg, parts[0]+"."+parts[1], signature); err != nil {
+		return err
+	}
+
+	var registered RegisteredClaims
+	if err := decodeSegment(parts[1], &registered); err != nil {
+		return fmt.Errorf("%w: bad payload: %v", ErrInvalidToken, err)
+	}
+	if err := v.validate(&registered); err != nil {
+		return err
+	}
+
+	if claims != nil {
+		if err := decodeSegment(parts[1], claims); err != nil {
+			return fmt.Errorf("%w: bad payload: %v", ErrInvalidToken, err)
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) verifySignature(alg, signingInput string, signature []byte) error {
+	switch alg {
+	case "HS256":
+		if len(v.keys.HMACSecret) == 0 {
+			break
+		}
+		mac := hmac.New(sha256.New, v.keys.HMACSecret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+		}
+		return nil
+	case "RS256":
+		if v.keys.RSAPublicKey == nil {
+			break
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(v.keys.RSAPublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unexpected algorithm %q", ErrInvalidToken, alg)
+}
+
+func (v *Verifier) validate(claims *RegisteredClaims) error {
+	now := v.now()
+
+	if claims.ExpiresAt != 0 && now.After(time.Unix(claims.ExpiresAt, 0).Add(v.Leeway)) {
+		return ErrExpiredToken
+	}
+	if claims.NotBefore != 0 && now.Add(v.Leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+	if v.Issuer != "" && claims.Issuer != v.Issuer {
+		return fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.Audience != "" {
+		for _, audience := range claims.Audience {
+			if audience == v.Audience {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	return nil
+}
+
+// SignHS256 encodes claims as the payload of a token signed with secret.
+func SignHS256(claims interface{}, secret []byte) (string, error) {
+	if len(secret) == 0 {
+		return "", errors.New("jwt: no signing secret")
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("jwt: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) +
+		"." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// ParseRSAPublicKey reads an RSA public key from PEM, either as PKIX
+// ("PUBLIC KEY") or PKCS #1 ("RSA PUBLIC KEY").
+func ParseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwt: no PEM block found")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: %w", err)
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("jwt: public key is not an RSA key")
+		}
+		return rsaKey, nil
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("jwt: unsupported PEM block %q", block.Type)
+	}
+}
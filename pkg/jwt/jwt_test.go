@@ -0,0 +1,294 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testSecret = []byte("test-secret")
+	testNow    = time.Unix(1700000000, 0)
+)
+
+func segment(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshalling %v: %v", v, err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, claims interface{}) string {
+	t.Helper()
+	signingInput := segment(t, map[string]string{"alg": "RS256", "typ": "JWT"}) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("signing: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func signHS256(t *testing.T, claims interface{}) string {
+	t.Helper()
+	token, err := SignHS256(claims, testSecret)
+	if err != nil {
+		t.Fatalf("SignHS256() error = %v", err)
+	}
+	return token
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %v", err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)})
+
+	valid := RegisteredClaims{Subject: "alice", ExpiresAt: testNow.Add(time.Hour).Unix()}
+	validToken := signHS256(t, valid)
+	parts := strings.Split(validToken, ".")
+
+	// A token that claims HS256 but is signed with the RSA public key, the
+	// classic attempt to make an RS256 verifier accept a forged token.
+	confused, err := SignHS256(valid, publicPEM)
+	if err != nil {
+		t.Fatalf("SignHS256() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		keys    Keys
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "valid HS256",
+			keys:  Keys{HMACSecret: testSecret},
+			token: validToken,
+		},
+		{
+			name:  "valid RS256",
+			keys:  Keys{RSAPublicKey: &rsaKey.PublicKey},
+			token: signRS256(t, rsaKey, valid),
+		},
+		{
+			name:    "alg none",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   segment(t, map[string]string{"alg": "none"}) + "." + parts[1] + ".",
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "HS256 token for an RS256 verifier",
+			keys:    Keys{RSAPublicKey: &rsaKey.PublicKey},
+			token:   confused,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "RS256 token for an HS256 verifier",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   signRS256(t, rsaKey, valid),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "header algorithm changed",
+			keys:    Keys{HMACSecret: testSecret, RSAPublicKey: &rsaKey.PublicKey},
+			token:   segment(t, map[string]string{"alg": "RS256", "typ": "JWT"}) + "." + parts[1] + "." + parts[2],
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "wrong secret",
+			keys:    Keys{HMACSecret: []byte("other-secret")},
+			token:   validToken,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "payload changed",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   parts[0] + "." + segment(t, RegisteredClaims{Subject: "admin", ExpiresAt: valid.ExpiresAt}) + "." + parts[2],
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "expired",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   signHS256(t, RegisteredClaims{ExpiresAt: testNow.Add(-time.Minute).Unix()}),
+			wantErr: ErrExpiredToken,
+		},
+		{
+			name:    "not valid yet",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   signHS256(t, RegisteredClaims{NotBefore: testNow.Add(time.Minute).Unix()}),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:  "already valid",
+			keys:  Keys{HMACSecret: testSecret},
+			token: signHS256(t, RegisteredClaims{NotBefore: testNow.Add(-time.Minute).Unix()}),
+		},
+		{
+			name:    "two parts",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   parts[0] + "." + parts[1],
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "header is not base64",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   "%%%." + parts[1] + "." + parts[2],
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "header is not JSON",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   base64.RawURLEncoding.EncodeToString([]byte("HS256")) + "." + parts[1] + "." + parts[2],
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "signature is not base64",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   parts[0] + "." + parts[1] + ".%%%",
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "empty token",
+			keys:    Keys{HMACSecret: testSecret},
+			token:   "",
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewVerifier(tt.keys)
+			if err != nil {
+				t.Fatalf("NewVerifier() error = %v", err)
+			}
+			verifier.now = func() time.Time { return testNow }
+
+			var claims RegisteredClaims
+			err = verifier.Verify(tt.token, &claims)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Verify() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyClaims(t *testing.T) {
+	tests := []struct {
+		name     string
+		issuer   string
+		audience string
+		leeway   time.Duration
+		claims   RegisteredClaims
+		wantErr  error
+	}{
+		{
+			name:   "expired within leeway",
+			leeway: time.Minute,
+			claims: RegisteredClaims{ExpiresAt: testNow.Add(-30 * time.Second).Unix()},
+		},
+		{
+			name:    "expired beyond leeway",
+			leeway:  time.Minute,
+			claims:  RegisteredClaims{ExpiresAt: testNow.Add(-2 * time.Minute).Unix()},
+			wantErr: ErrExpiredToken,
+		},
+		{
+			name:   "not valid yet within leeway",
+			leeway: time.Minute,
+			claims: RegisteredClaims{NotBefore: testNow.Add(30 * time.Second).Unix()},
+		},
+		{
+			name:   "expected issuer",
+			issuer: "song-library",
+			claims: RegisteredClaims{Issuer: "song-library"},
+		},
+		{
+			name:    "other issuer",
+			issuer:  "song-library",
+			claims:  RegisteredClaims{Issuer: "someone-else"},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:     "audience in a list",
+			audience: "api",
+			claims:   RegisteredClaims{Audience: Audience{"web", "api"}},
+		},
+		{
+			name:     "missing audience",
+			audience: "api",
+			claims:   RegisteredClaims{},
+			wantErr:  ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewVerifier(Keys{HMACSecret: testSecret})
+			if err != nil {
+				t.Fatalf("NewVerifier() error = %v", err)
+			}
+			verifier.Issuer = tt.issuer
+			verifier.Audience = tt.audience
+			verifier.Leeway = tt.leeway
+			verifier.now = func() time.Time { return testNow }
+
+			err = verifier.Verify(signHS256(t, tt.claims), nil)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Verify() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAudienceJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want Audience
+	}{
+		{data: `"api"`, want: Audience{"api"}},
+		{data: `["web","api"]`, want: Audience{"web", "api"}},
+	}
+
+	for _, tt := range tests {
+		var got Audience
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+			t.Fatalf("unmarshalling %s: %v", tt.data, err)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("unmarshalling %s = %v, want %v", tt.data, got, tt.want)
+		}
+
+		data, err := json.Marshal(got)
+		if err != nil {
+			t.Fatalf("marshalling %v: %v", got, err)
+		}
+		if string(data) != tt.data {
+			t.Errorf("marshalling %v = %s, want %s", got, data, tt.data)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 const (
 	RequestIdTrackerKey = "request_id"
+	PrincipalTrackerKey = "principal"
 )
 
 type Logger struct {
@@ -64,22 +65,22 @@ func New(level string) *Logger {
 }
 
 func (l *Logger) Debug(ctx context.Context, message string, fields ...zap.Field) {
-	fields = l.appendRequestId(ctx, fields...)
+	fields = l.appendContextFields(ctx, fields...)
 	l.log("debug", message, fields...)
 }
 
 func (l *Logger) Info(ctx context.Context, message string, fields ...zap.Field) {
-	fields = l.appendRequestId(ctx, fields...)
+	fields = l.appendContextFields(ctx, fields...)
 	l.log("info", message, fields...)
 }
 
 func (l *Logger) Warn(ctx context.Context, message string, fields ...zap.Field) {
-	fields = l.appendRequestId(ctx, fields...)
+	fields = l.appendContextFields(ctx, fields...)
 	l.log("warn", message, fields...)
 }
 
 func (l *Logger) Error(ctx context.Context, message string, fields ...zap.Field) {
-	fields = l.appendRequestId(ctx, fields...)
+	fields = l.appendContextFields(ctx, fields...)
 	l.log("error", message, fields...)
 }
 
@@ -88,12 +89,17 @@ func (l *Logger) Fatal(message string, fields ...zap.Field) {
 	os.Exit(1)
 }
 
-func (l *Logger) appendRequestId(ctx context.Context, fields ...zap.Field) []zap.Field {
+func (l *Logger) appendContextFields(ctx context.Context, fields ...zap.Field) []zap.Field {
 	requestIdTracker := ctx.Value(RequestIdTrackerKey)
 	if requestIdTracker != nil {
 		fields = append(fields, zap.String(RequestIdTrackerKey, requestIdTracker.(string)))
 	}
 
+	principalTracker := ctx.Value(PrincipalTrackerKey)
+	if principalTracker != nil {
+		fields = append(fields, zap.String(PrincipalTrackerKey, principalTracker.(string)))
+	}
+
 	return fields
 }
 
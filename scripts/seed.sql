@@ -1,42 +1,84 @@
-INSERT INTO songs (group_name, song_name, text, link, release_date, created_at, updated_at) VALUES
-('Radiohead', 'Karma Police', 'Karma police, arrest this man\nHe talks in maths...', 'https://www.youtube.com/watch?v=1uYWYWPc9HU', '1997-08-25', NOW(), NOW()),
-('Red Hot Chili Peppers', 'Californication', 'Psychic spies from China try to steal your minds elation...', 'https://www.youtube.com/watch?v=YlUKcNNmywk', '1999-06-08', NOW(), NOW()),
-('Arctic Monkeys', 'Do I Wanna Know?', 'Have you got colour in your cheeks?...', 'https://www.youtube.com/watch?v=bpOSxM0rNPM', '2013-06-19', NOW(), NOW()),
-('The Strokes', 'Last Nite', 'Last night she said\nOh baby I feel so down...', 'https://www.youtube.com/watch?v=TOypSnKFHrE', '2001-10-09', NOW(), NOW()),
-('Coldplay', 'Yellow', 'Look at the stars\nLook how they shine for you...', 'https://www.youtube.com/watch?v=yKNxeF4KMsY', '2000-06-26', NOW(), NOW()),
+INSERT INTO artists (name) VALUES
+('Radiohead'),
+('Red Hot Chili Peppers'),
+('Arctic Monkeys'),
+('The Strokes'),
+('Coldplay'),
+('Queen'),
+('The Beatles'),
+('Nirvana'),
+('Metallica'),
+('Pink Floyd'),
+('ABBA'),
+('Michael Jackson'),
+('Madonna'),
+('Whitney Houston'),
+('Britney Spears'),
+('Imagine Dragons'),
+('Twenty One Pilots'),
+('The Lumineers'),
+('Glass Animals'),
+('Tame Impala'),
+('Ludwig van Beethoven'),
+('Wolfgang Amadeus Mozart'),
+('Claude Debussy'),
+('Frédéric Chopin'),
+('Johann Sebastian Bach'),
+('Louis Armstrong'),
+('Frank Sinatra'),
+('Ella Fitzgerald'),
+('Duke Ellington'),
+('Miles Davis'),
+('Ed Sheeran'),
+('Adele'),
+('Lady Gaga'),
+('Taylor Swift'),
+('The Weeknd')
+ON CONFLICT (normalized_name) DO NOTHING;
 
-('Queen', 'Bohemian Rhapsody', 'Is this the real life?\nIs this just fantasy?\nCaught in a landslide...', 'https://www.youtube.com/watch?v=fJ9rUzIMcZQ', '1975-10-31', NOW(), NOW()),
-('The Beatles', 'Yesterday', 'Yesterday,\nAll my troubles seemed so far away...', 'https://www.youtube.com/watch?v=NrgmdOz227I', '1965-08-06', NOW(), NOW()),
-('Nirvana', 'Smells Like Teen Spirit', 'Load up on guns, bring your friends\nIt''s fun to lose and to pretend...', 'https://www.youtube.com/watch?v=hTWKbfoikeg', '1991-09-10', NOW(), NOW()),
-('Metallica', 'Nothing Else Matters', 'So close, no matter how far\nCouldn''t be much more from the heart...', 'https://www.youtube.com/watch?v=tAGnKpE4NCI', '1991-08-12', NOW(), NOW()),
-('Pink Floyd', 'Another Brick in the Wall', 'We don''t need no education\nWe don''t need no thought control...', 'https://www.youtube.com/watch?v=YR5ApYxkU-U', '1979-11-30', NOW(), NOW()),
+INSERT INTO songs (artist_id, group_name, song_name, text, link, release_date, created_at, updated_at)
+SELECT a.id, a.name, v.song_name, v.text, v.link, v.release_date::date, NOW(), NOW()
+FROM (VALUES
+('Radiohead', 'Karma Police', 'Karma police, arrest this man\nHe talks in maths...', 'https://www.youtube.com/watch?v=1uYWYWPc9HU', '1997-08-25'),
+('Red Hot Chili Peppers', 'Californication', 'Psychic spies from China try to steal your minds elation...', 'https://www.youtube.com/watch?v=YlUKcNNmywk', '1999-06-08'),
+('Arctic Monkeys', 'Do I Wanna Know?', 'Have you got colour in your cheeks?...', 'https://www.youtube.com/watch?v=bpOSxM0rNPM', '2013-06-19'),
+('The Strokes', 'Last Nite', 'Last night she said\nOh baby I feel so down...', 'https://www.youtube.com/watch?v=TOypSnKFHrE', '2001-10-09'),
+('Coldplay', 'Yellow', 'Look at the stars\nLook how they shine for you...', 'https://www.youtube.com/watch?v=yKNxeF4KMsY', '2000-06-26'),
 
-('ABBA', 'Dancing Queen', 'You can dance, you can jive\nHaving the time of your life...', 'https://www.youtube.com/watch?v=xFrGuyw1V8s', '1976-08-15', NOW(), NOW()),
-('Michael Jackson', 'Billie Jean', 'She was more like a beauty queen from a movie scene\nI said don''t mind...', 'https://www.youtube.com/watch?v=Zi_XLOBDo_Y', '1983-01-02', NOW(), NOW()),
-('Madonna', 'Like a Prayer', 'Life is a mystery\nEveryone must stand alone...', 'https://www.youtube.com/watch?v=79fzeNUqQbQ', '1989-03-03', NOW(), NOW()),
-('Whitney Houston', 'I Will Always Love You', 'If I should stay\nI would only be in your way...', 'https://www.youtube.com/watch?v=3JWTaaS7LdU', '1992-11-03', NOW(), NOW()),
-('Britney Spears', 'Baby One More Time', 'Oh baby, baby\nHow was I supposed to know...', 'https://www.youtube.com/watch?v=C-u5WLJ9Yk4', '1998-10-23', NOW(), NOW()),
+('Queen', 'Bohemian Rhapsody', 'Is this the real life?\nIs this just fantasy?\nCaught in a landslide...', 'https://www.youtube.com/watch?v=fJ9rUzIMcZQ', '1975-10-31'),
+('The Beatles', 'Yesterday', 'Yesterday,\nAll my troubles seemed so far away...', 'https://www.youtube.com/watch?v=NrgmdOz227I', '1965-08-06'),
+('Nirvana', 'Smells Like Teen Spirit', 'Load up on guns, bring your friends\nIt''s fun to lose and to pretend...', 'https://www.youtube.com/watch?v=hTWKbfoikeg', '1991-09-10'),
+('Metallica', 'Nothing Else Matters', 'So close, no matter how far\nCouldn''t be much more from the heart...', 'https://www.youtube.com/watch?v=tAGnKpE4NCI', '1991-08-12'),
+('Pink Floyd', 'Another Brick in the Wall', 'We don''t need no education\nWe don''t need no thought control...', 'https://www.youtube.com/watch?v=YR5ApYxkU-U', '1979-11-30'),
 
-('Imagine Dragons', 'Believer', 'First things first\nI''ma say all the words inside my head...', 'https://www.youtube.com/watch?v=7wtfhZwyrcc', '2017-02-01', NOW(), NOW()),
-('Twenty One Pilots', 'Stressed Out', 'I wish I found some better sounds\nNo ones ever heard...', 'https://www.youtube.com/watch?v=pXRviuL6vMY', '2015-04-28', NOW(), NOW()),
-('The Lumineers', 'Ho Hey', 'I''ve been trying to do it right\nI''ve been living a lonely life...', 'https://www.youtube.com/watch?v=zvCBSSwgtg4', '2012-06-04', NOW(), NOW()),
-('Glass Animals', 'Heat Waves', 'Last night all I think about is you\nDon''t stop baby...', 'https://www.youtube.com/watch?v=mRD0-GxqHVo', '2020-06-29', NOW(), NOW()),
-('Tame Impala', 'The Less I Know The Better', 'Someone said they left together\nI ran out the door...', 'https://www.youtube.com/watch?v=2SUwOgmvzK4', '2015-11-26', NOW(), NOW()),
+('ABBA', 'Dancing Queen', 'You can dance, you can jive\nHaving the time of your life...', 'https://www.youtube.com/watch?v=xFrGuyw1V8s', '1976-08-15'),
+('Michael Jackson', 'Billie Jean', 'She was more like a beauty queen from a movie scene\nI said don''t mind...', 'https://www.youtube.com/watch?v=Zi_XLOBDo_Y', '1983-01-02'),
+('Madonna', 'Like a Prayer', 'Life is a mystery\nEveryone must stand alone...', 'https://www.youtube.com/watch?v=79fzeNUqQbQ', '1989-03-03'),
+('Whitney Houston', 'I Will Always Love You', 'If I should stay\nI would only be in your way...', 'https://www.youtube.com/watch?v=3JWTaaS7LdU', '1992-11-03'),
+('Britney Spears', 'Baby One More Time', 'Oh baby, baby\nHow was I supposed to know...', 'https://www.youtube.com/watch?v=C-u5WLJ9Yk4', '1998-10-23'),
 
-('Ludwig van Beethoven', 'Für Elise', '[Instrumental composition]', 'https://www.youtube.com/watch?v=_mVW8tgGY_w', '1810-01-01', NOW(), NOW()),
-('Wolfgang Amadeus Mozart', 'Turkish March', '[Instrumental composition]', 'https://www.youtube.com/watch?v=HMjQygwPI1c', '1783-01-01', NOW(), NOW()),
-('Claude Debussy', 'Clair de Lune', '[Instrumental composition]', 'https://www.youtube.com/watch?v=CvFH_6DNRCY', '1905-01-01', NOW(), NOW()),
-('Frédéric Chopin', 'Nocturne No. 2', '[Instrumental composition]', 'https://www.youtube.com/watch?v=9E6b3swbnWg', '1830-01-01', NOW(), NOW()),
-('Johann Sebastian Bach', 'Toccata and Fugue in D minor', '[Instrumental composition]', 'https://www.youtube.com/watch?v=ho9rZjlsyYY', '1703-01-01', NOW(), NOW()),
+('Imagine Dragons', 'Believer', 'First things first\nI''ma say all the words inside my head...', 'https://www.youtube.com/watch?v=7wtfhZwyrcc', '2017-02-01'),
+('Twenty One Pilots', 'Stressed Out', 'I wish I found some better sounds\nNo ones ever heard...', 'https://www.youtube.com/watch?v=pXRviuL6vMY', '2015-04-28'),
+('The Lumineers', 'Ho Hey', 'I''ve been trying to do it right\nI''ve been living a lonely life...', 'https://www.youtube.com/watch?v=zvCBSSwgtg4', '2012-06-04'),
+('Glass Animals', 'Heat Waves', 'Last night all I think about is you\nDon''t stop baby...', 'https://www.youtube.com/watch?v=mRD0-GxqHVo', '2020-06-29'),
+('Tame Impala', 'The Less I Know The Better', 'Someone said they left together\nI ran out the door...', 'https://www.youtube.com/watch?v=2SUwOgmvzK4', '2015-11-26'),
 
-('Louis Armstrong', 'What a Wonderful World', 'I see trees of green, red roses too\nI see them bloom for me and you...', 'https://www.youtube.com/watch?v=CWzrABouyeE', '1967-09-01', NOW(), NOW()),
-('Frank Sinatra', 'My Way', 'And now, the end is near\nAnd so I face the final curtain...', 'https://www.youtube.com/watch?v=qQzdAsjWGPg', '1969-01-01', NOW(), NOW()),
-('Ella Fitzgerald', 'Summertime', 'Summertime and the livin'' is easy\nFish are jumpin''...', 'https://www.youtube.com/watch?v=XivELBdxVRM', '1957-01-01', NOW(), NOW()),
-('Duke Ellington', 'Take the "A" Train', '[Jazz composition]', 'https://www.youtube.com/watch?v=cb2w2m1JmCY', '1941-01-01', NOW(), NOW()),
-('Miles Davis', 'So What', '[Jazz composition]', 'https://www.youtube.com/watch?v=zqNTltOGh5c', '1959-08-17', NOW(), NOW()),
+('Ludwig van Beethoven', 'Für Elise', '[Instrumental composition]', 'https://www.youtube.com/watch?v=_mVW8tgGY_w', '1810-01-01'),
+('Wolfgang Amadeus Mozart', 'Turkish March', '[Instrumental composition]', 'https://www.youtube.com/watch?v=HMjQygwPI1c', '1783-01-01'),
+('Claude Debussy', 'Clair de Lune', '[Instrumental composition]', 'https://www.youtube.com/watch?v=CvFH_6DNRCY', '1905-01-01'),
+('Frédéric Chopin', 'Nocturne No. 2', '[Instrumental composition]', 'https://www.youtube.com/watch?v=9E6b3swbnWg', '1830-01-01'),
+('Johann Sebastian Bach', 'Toccata and Fugue in D minor', '[Instrumental composition]', 'https://www.youtube.com/watch?v=ho9rZjlsyYY', '1703-01-01'),
 
-('Ed Sheeran', 'Shape of You', 'The club isn''t the best place to find a lover\nSo the bar is where I go...', 'https://www.youtube.com/watch?v=JGwWNGJdvx8', '2017-01-06', NOW(), NOW()),
-('Adele', 'Hello', 'Hello, it''s me\nI was wondering if after all these years...', 'https://www.youtube.com/watch?v=YQHsXMglC9A', '2015-10-23', NOW(), NOW()),
-('Lady Gaga', 'Bad Romance', 'Oh-oh-oh-oh-oh, oh-oh-oh-oh, oh-oh-oh\nCaught in a bad romance...', 'https://www.youtube.com/watch?v=qrO4YZeyl0I', '2009-10-26', NOW(), NOW()),
-('Taylor Swift', 'Shake It Off', 'I stay out too late\nGot nothing in my brain...', 'https://www.youtube.com/watch?v=nfWlot6h_JM', '2014-08-18', NOW(), NOW()),
-('The Weeknd', 'Blinding Lights', 'I''ve been tryin'' to call\nI''ve been on my own for long enough...', 'https://www.youtube.com/watch?v=4NRXx6U8ABQ', '2019-11-29', NOW(), NOW())
+('Louis Armstrong', 'What a Wonderful World', 'I see trees of green, red roses too\nI see them bloom for me and you...', 'https://www.youtube.com/watch?v=CWzrABouyeE', '1967-09-01'),
+('Frank Sinatra', 'My Way', 'And now, the end is near\nAnd so I face the final curtain...', 'https://www.youtube.com/watch?v=qQzdAsjWGPg', '1969-01-01'),
+('Ella Fitzgerald', 'Summertime', 'Summertime and the livin'' is easy\nFish are jumpin''...', 'https://www.youtube.com/watch?v=XivELBdxVRM', '1957-01-01'),
+('Duke Ellington', 'Take the "A" Train', '[Jazz composition]', 'https://www.youtube.com/watch?v=cb2w2m1JmCY', '1941-01-01'),
+('Miles Davis', 'So What', '[Jazz composition]', 'https://www.youtube.com/watch?v=zqNTltOGh5c', '1959-08-17'),
+
+('Ed Sheeran', 'Shape of You', 'The club isn''t the best place to find a lover\nSo the bar is where I go...', 'https://www.youtube.com/watch?v=JGwWNGJdvx8', '2017-01-06'),
+('Adele', 'Hello', 'Hello, it''s me\nI was wondering if after all these years...', 'https://www.youtube.com/watch?v=YQHsXMglC9A', '2015-10-23'),
+('Lady Gaga', 'Bad Romance', 'Oh-oh-oh-oh-oh, oh-oh-oh-oh, oh-oh-oh\nCaught in a bad romance...', 'https://www.youtube.com/watch?v=qrO4YZeyl0I', '2009-10-26'),
+('Taylor Swift', 'Shake It Off', 'I stay out too late\nGot nothing in my brain...', 'https://www.youtube.com/watch?v=nfWlot6h_JM', '2014-08-18'),
+('The Weeknd', 'Blinding Lights', 'I''ve been tryin'' to call\nI''ve been on my own for long enough...', 'https://www.youtube.com/watch?v=4NRXx6U8ABQ', '2019-11-29')
+) AS v(group_name, song_name, text, link, release_date)
+JOIN artists a ON a.normalized_name = lower(btrim(v.group_name));